@@ -0,0 +1,66 @@
+// Suggests the post closest to the requested path, embedded in the 404 page
+var maxDistanceRatio = 0.4;
+
+// decode falls back to the raw path for malformed escapes, like /%E0%A4%A
+function decode(path) {
+    try {
+        return decodeURIComponent(path);
+    } catch (e) {
+        return path;
+    }
+}
+
+function normalize(path) {
+    return decode(path).toLowerCase().replace(/^\/+|\/+$/g, '').replace(/\.html$/, '');
+}
+
+// levenshtein is the edit distance between a and b
+function levenshtein(a, b) {
+    var previous = [];
+    for (var j = 0; j <= b.length; j++) {
+        previous.push(j);
+    }
+    for (var i = 1; i <= a.length; i++) {
+        var current = [i];
+        for (j = 1; j <= b.length; j++) {
+            var cost = a.charAt(i - 1) === b.charAt(j - 1) ? 0 : 1;
+            current.push(Math.min(previous[j] + 1, current[j - 1] + 1, previous[j - 1] + cost));
+        }
+        previous = current;
+    }
+    return previous[b.length];
+}
+
+function closestPost(path, posts) {
+    var closest = null;
+    var closestDistance = Infinity;
+    posts.forEach(function(post) {
+        var distance = levenshtein(path, normalize(post.url));
+        if (distance < closestDistance) {
+            closest = post;
+            closestDistance = distance;
+        }
+    });
+    if (closest === null || closestDistance > Math.max(2, path.length * maxDistanceRatio)) {
+        return null;
+    }
+    return closest;
+}
+
+document.addEventListener('DOMContentLoaded', function() {
+    var postsElement = document.getElementById('not_found_posts');
+    var path = normalize(window.location.pathname);
+    if (postsElement === null || path === '') {
+        return;
+    }
+
+    var post = closestPost(path, JSON.parse(postsElement.textContent));
+    if (post === null) {
+        return;
+    }
+    var suggestion = document.querySelector('.not_found .suggestion');
+    var link = suggestion.querySelector('a');
+    link.href = post.url;
+    link.textContent = post.title;
+    suggestion.hidden = false;
+});
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/s12chung/go_homepage/go/content"
+	"github.com/s12chung/go_homepage/go/content/linkcheck"
+	"github.com/s12chung/go_homepage/go/content/models"
+	"github.com/s12chung/gostatic/go/app"
+)
+
+// checks the external links of the posts and markdown pages, results are cached in the link_check cache_path
+func main() {
+	log := app.DefaultLog()
+
+	settings := app.DefaultSettings()
+	contentSettings := content.DefaultSettings()
+	settings.Content = contentSettings
+	app.SettingsFromFile("./settings.json", settings, log)
+
+	models.Config(contentSettings.Models, log)
+	report, err := check(contentSettings.LinkCheck, log)
+	if err != nil {
+		log.Fatal(err)
+		os.Exit(1)
+	}
+
+	if len(report) != 0 {
+		fmt.Println(report)
+		os.Exit(1)
+	}
+}
+
+func check(settings *linkcheck.Settings, log logrus.FieldLogger) (linkcheck.Report, error) {
+	sources, err := linkcheck.PostSources()
+	if err != nil {
+		return nil, err
+	}
+	markdownSources, err := linkcheck.MarkdownSources(settings.MarkdownsPath)
+	if err != nil {
+		return nil, err
+	}
+	sources = append(sources, markdownSources...)
+
+	checker := linkcheck.NewExternalChecker(settings, linkcheck.NewHTTPClient(settings), log)
+	return checker.Check(sources)
+}
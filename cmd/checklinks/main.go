@@ -0,0 +1,41 @@
+package main
+
+import (
+	"os"
+
+	"github.com/s12chung/go_homepage/go/content"
+	"github.com/s12chung/go_homepage/go/content/linkcheck"
+	"github.com/s12chung/gostatic/go/app"
+)
+
+// checks the internal links, images and anchors of the generated site, run after generating
+func main() {
+	log := app.DefaultLog()
+
+	settings := app.DefaultSettings()
+	contentSettings := content.DefaultSettings()
+	settings.Content = contentSettings
+	app.SettingsFromFile("./settings.json", settings, log)
+
+	theContent := content.NewContent(settings.GeneratedPath, contentSettings, log)
+	urls, err := theContent.URLs()
+	if err != nil {
+		log.Fatal(err)
+		os.Exit(1)
+	}
+
+	checker := linkcheck.NewChecker(settings.GeneratedPath, urls, theContent.AssetsURL(), theContent.GeneratedAssetsPath(), log)
+	problems, err := checker.Check()
+	if err != nil {
+		log.Fatal(err)
+		os.Exit(1)
+	}
+
+	for _, problem := range problems {
+		log.Error(problem)
+	}
+	if len(problems) != 0 {
+		log.Fatalf("%v broken links found", len(problems))
+		os.Exit(1)
+	}
+}
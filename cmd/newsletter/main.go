@@ -0,0 +1,86 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/s12chung/go_homepage/go/content"
+	"github.com/s12chung/go_homepage/go/content/atom"
+	"github.com/s12chung/go_homepage/go/content/models"
+	"github.com/s12chung/go_homepage/go/content/newsletter"
+	"github.com/s12chung/gostatic/go/app"
+	"github.com/s12chung/gostatic/go/lib/webpack"
+)
+
+const sinceFormat = "2006-01-02"
+
+// renders a post, or the posts published since a date, as email-ready .eml, .html and .txt files in the newsletter output_path
+func main() {
+	postFilename := flag.String("post", "", "filename of the post to render")
+	since := flag.String("since", "", "render the posts published on or after this date, like 2018-01-02")
+	flag.Parse()
+
+	log := app.DefaultLog()
+
+	settings := app.DefaultSettings()
+	contentSettings := content.DefaultSettings()
+	settings.Content = contentSettings
+	app.SettingsFromFile("./settings.json", settings, log)
+	contentSettings.FillDefaults()
+
+	models.Config(contentSettings.Models, log)
+	posts, err := selectPosts(*postFilename, *since)
+	if err != nil {
+		log.Fatal(err)
+		os.Exit(1)
+	}
+
+	// the post images are in the webpack manifest of the generated site
+	manifest := webpack.NewWebpack(settings.GeneratedPath, contentSettings.Webpack, log)
+	err = render(posts, contentSettings, newsletter.ManifestImageURL(manifest.ManifestURL), log)
+	if err != nil {
+		log.Fatal(err)
+		os.Exit(1)
+	}
+}
+
+func selectPosts(postFilename, since string) ([]*models.Post, error) {
+	if postFilename != "" {
+		post, err := models.NewPost(postFilename)
+		if err != nil {
+			return nil, err
+		}
+		return []*models.Post{post}, nil
+	}
+	if since == "" {
+		return nil, fmt.Errorf("set -post or -since")
+	}
+
+	sinceTime, err := time.Parse(sinceFormat, since)
+	if err != nil {
+		return nil, err
+	}
+	return newsletter.PostsSince(sinceTime)
+}
+
+func render(posts []*models.Post, settings *content.Settings, imageURL atom.ImageURL, log logrus.FieldLogger) error {
+	if len(posts) == 0 {
+		log.Warn("No posts to render")
+	}
+	for _, post := range posts {
+		email, err := newsletter.NewEmail(post, settings.Site, settings.Newsletter, imageURL)
+		if err != nil {
+			return err
+		}
+		err = email.Write(settings.Newsletter.OutputPath, post.Filename)
+		if err != nil {
+			return err
+		}
+		log.Infof("Rendered %v to %v", post.Filename, settings.Newsletter.OutputPath)
+	}
+	return nil
+}
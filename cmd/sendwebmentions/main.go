@@ -0,0 +1,54 @@
+package main
+
+import (
+	"os"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/s12chung/go_homepage/go/content"
+	"github.com/s12chung/go_homepage/go/content/models"
+	"github.com/s12chung/go_homepage/go/content/webmention"
+	"github.com/s12chung/gostatic/go/app"
+)
+
+// sends webmentions to the external links of new and updated posts, run after deploying,
+// the webmentions sent are cached in the webmention cache_path
+func main() {
+	log := app.DefaultLog()
+
+	settings := app.DefaultSettings()
+	contentSettings := content.DefaultSettings()
+	settings.Content = contentSettings
+	app.SettingsFromFile("./settings.json", settings, log)
+	contentSettings.FillDefaults()
+
+	models.Config(contentSettings.Models, log)
+	results, err := send(contentSettings, log)
+	if err != nil {
+		log.Fatal(err)
+		os.Exit(1)
+	}
+
+	failed := 0
+	for _, result := range results {
+		if result.Failed() {
+			failed++
+			log.Error(result)
+		} else {
+			log.Info(result)
+		}
+	}
+	if failed != 0 {
+		log.Fatalf("%v webmentions failed", failed)
+		os.Exit(1)
+	}
+}
+
+func send(settings *content.Settings, log logrus.FieldLogger) ([]*webmention.Result, error) {
+	sources, err := webmention.PostSources(settings.Site)
+	if err != nil {
+		return nil, err
+	}
+	sender := webmention.NewSender(settings.Webmention, webmention.NewHTTPClient(settings.Webmention), log)
+	return sender.Send(sources)
+}
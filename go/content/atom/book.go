@@ -0,0 +1,54 @@
+package atom
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/s12chung/go_homepage/go/content/reading"
+	"github.com/s12chung/go_homepage/go/content/site"
+
+	"github.com/s12chung/gostatic-packages/atom"
+)
+
+// ReadingURL is the URL of the feed of read books
+const ReadingURL = reading.URL + ".atom"
+
+// BooksToHTMLEntries rates the books out of maxRating
+func BooksToHTMLEntries(books []*reading.Book, settings *site.Settings, maxRating int) []*atom.HTMLEntry {
+	if settings.FeedEntryLimit > 0 && settings.FeedEntryLimit < len(books) {
+		books = books[0:settings.FeedEntryLimit]
+	}
+	htmlEntries := make([]*atom.HTMLEntry, len(books))
+	for i, book := range books {
+		htmlEntries[i] = BookToHTMLEntry(book, settings, maxRating)
+	}
+	return htmlEntries
+}
+
+// BookToHTMLEntry is identified by the book on the reading page, so the ID is the same before and after a review is written,
+// the entry content links to the book's review page
+func BookToHTMLEntry(book *reading.Book, settings *site.Settings, maxRating int) *atom.HTMLEntry {
+	id := settings.AbsoluteURL(reading.URL + "#" + book.Slug())
+
+	summary := "by " + strings.Join(book.Authors, ", ")
+	if book.Rating > 0 {
+		summary += fmt.Sprintf(", rated %v/%v", book.Rating, maxRating)
+	}
+
+	htmlContent := fmt.Sprintf("<p>%v</p>", html.EscapeString(summary))
+	if book.HasReview() {
+		bookURL := settings.AbsoluteURL(book.URL())
+		htmlContent += AbsoluteHTML(book.ReviewHTML(), bookURL, nil)
+		htmlContent += fmt.Sprintf(`<p><a href="%v">Read the review</a></p>`, html.EscapeString(bookURL))
+	}
+
+	return &atom.HTMLEntry{
+		ID:          id,
+		Title:       book.Title,
+		Updated:     book.SortedDate(),
+		HTMLContent: htmlContent,
+		Summary:     summary,
+		Published:   book.SortedDate(),
+	}
+}
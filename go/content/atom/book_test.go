@@ -0,0 +1,84 @@
+package atom
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/s12chung/go_homepage/go/content/reading"
+	"github.com/s12chung/go_homepage/go/content/site"
+	"github.com/s12chung/gostatic/go/test"
+
+	"github.com/s12chung/gostatic-packages/atom"
+)
+
+func TestBooksToHTMLEntries(t *testing.T) {
+	books := []*reading.Book{{}, {}, {}}
+	settings := site.DefaultSettings()
+
+	settings.FeedEntryLimit = 2
+	test.AssertLabel(t, "len(entries)", len(BooksToHTMLEntries(books, settings, 5)), 2)
+	settings.FeedEntryLimit = 0
+	test.AssertLabel(t, "len(entries)", len(BooksToHTMLEntries(books, settings, 5)), 3)
+}
+
+func TestBookToHTMLEntry(t *testing.T) {
+	readAt := time.Date(2018, 7, 15, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		maxRating int
+		book      *reading.Book
+		exp       *atom.HTMLEntry
+	}{
+		{
+			5,
+			&reading.Book{Title: "Antifragile", Authors: []string{"Nassim Nicholas Taleb"}, ReadAt: readAt},
+			&atom.HTMLEntry{
+				ID:          "https://test.com/reading#antifragile",
+				Title:       "Antifragile",
+				Updated:     readAt,
+				HTMLContent: "<p>by Nassim Nicholas Taleb</p>",
+				Summary:     "by Nassim Nicholas Taleb",
+				Published:   readAt,
+			},
+		},
+		{
+			5,
+			&reading.Book{Title: "Sapiens", Authors: []string{"Yuval Noah Harari", "A & B"}, Rating: 4, ReadAt: readAt, Review: "[Notes](notes)"},
+			&atom.HTMLEntry{
+				ID:          "https://test.com/reading#sapiens",
+				Title:       "Sapiens",
+				Updated:     readAt,
+				HTMLContent: "<p>by Yuval Noah Harari, A &amp; B, rated 4/5</p><p><a href=\"https://test.com/reading/notes\">Notes</a></p>\n<p><a href=\"https://test.com/reading/sapiens\">Read the review</a></p>",
+				Summary:     "by Yuval Noah Harari, A & B, rated 4/5",
+				Published:   readAt,
+			},
+		},
+		{
+			10,
+			&reading.Book{Title: "Impro", Authors: []string{"Keith Johnstone"}, Rating: 8, ReadAt: readAt},
+			&atom.HTMLEntry{
+				ID:          "https://test.com/reading#impro",
+				Title:       "Impro",
+				Updated:     readAt,
+				HTMLContent: "<p>by Keith Johnstone, rated 8/10</p>",
+				Summary:     "by Keith Johnstone, rated 8/10",
+				Published:   readAt,
+			},
+		},
+	}
+
+	settings := site.DefaultSettings()
+	settings.URL = "https://test.com"
+	for testCaseIndex, tc := range testCases {
+		context := test.NewContext().SetFields(test.ContextFields{
+			"index": testCaseIndex,
+			"title": tc.book.Title,
+		})
+
+		got := BookToHTMLEntry(tc.book, settings, tc.maxRating)
+		if !cmp.Equal(got, tc.exp) {
+			t.Error(context.DiffString("Result", got, tc.exp, cmp.Diff(got, tc.exp)))
+		}
+	}
+}
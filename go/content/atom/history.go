@@ -0,0 +1,88 @@
+package atom
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+
+	"github.com/s12chung/go_homepage/go/content/models"
+)
+
+// Feed paging and archiving, see https://tools.ietf.org/html/rfc5005
+const historyNamespace = "http://purl.org/syndication/history/1.0"
+
+const CurrentURL = "/posts.atom"
+
+func ArchiveURL(number int) string {
+	return fmt.Sprintf("/posts-archive-%v.atom", number)
+}
+
+// ArchiveCount returns the number of archive feeds, only full archives are made, so they never change
+func ArchiveCount(postCount, entryLimit int) int {
+	if entryLimit <= 0 {
+		return 0
+	}
+	return postCount / entryLimit
+}
+
+// ArchivePosts returns the posts of the archive number, starting at 1 for the oldest posts.
+// posts are sorted by newest first, like the returned posts.
+func ArchivePosts(posts []*models.Post, number, entryLimit int) []*models.Post {
+	if number < 1 || number > ArchiveCount(len(posts), entryLimit) {
+		return nil
+	}
+	end := len(posts) - (number-1)*entryLimit
+	return posts[end-entryLimit : end]
+}
+
+type History struct {
+	Archive     bool
+	Current     string
+	PrevArchive string
+	NextArchive string
+}
+
+// AddTo adds the History elements to the rendered feed
+func (history *History) AddTo(feed []byte) ([]byte, error) {
+	var elements bytes.Buffer
+	if history.Archive {
+		elements.WriteString(fmt.Sprintf("\n  <fh:archive xmlns:fh=\"%v\"/>", historyNamespace))
+	}
+	links := []struct {
+		rel  string
+		href string
+	}{
+		{"current", history.Current},
+		{"prev-archive", history.PrevArchive},
+		{"next-archive", history.NextArchive},
+	}
+	for _, link := range links {
+		if link.href == "" {
+			continue
+		}
+		elements.WriteString(fmt.Sprintf("\n  <link rel=\"%v\" href=\"", link.rel))
+		err := xml.EscapeText(&elements, []byte(link.href))
+		if err != nil {
+			return nil, err
+		}
+		elements.WriteString("\"/>")
+	}
+	if elements.Len() == 0 {
+		return feed, nil
+	}
+
+	feedStart := bytes.Index(feed, []byte("<feed"))
+	if feedStart == -1 {
+		return nil, fmt.Errorf("no feed element found to add history")
+	}
+	feedStartEnd := bytes.IndexByte(feed[feedStart:], '>')
+	if feedStartEnd == -1 {
+		return nil, fmt.Errorf("feed element is not closed")
+	}
+	insertAt := feedStart + feedStartEnd + 1
+
+	result := make([]byte, 0, len(feed)+elements.Len())
+	result = append(result, feed[:insertAt]...)
+	result = append(result, elements.Bytes()...)
+	return append(result, feed[insertAt:]...), nil
+}
@@ -0,0 +1,116 @@
+package atom
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/s12chung/go_homepage/go/content/models"
+	"github.com/s12chung/gostatic/go/test"
+)
+
+func TestArchiveCount(t *testing.T) {
+	testCases := []struct {
+		postCount  int
+		entryLimit int
+		exp        int
+	}{
+		{0, 100, 0},
+		{99, 100, 0},
+		{100, 100, 1},
+		{250, 100, 2},
+		{5, 0, 0},
+		{5, 2, 2},
+	}
+
+	for testCaseIndex, tc := range testCases {
+		context := test.NewContext().SetFields(test.ContextFields{
+			"index":      testCaseIndex,
+			"postCount":  tc.postCount,
+			"entryLimit": tc.entryLimit,
+		})
+
+		got := ArchiveCount(tc.postCount, tc.entryLimit)
+		if got != tc.exp {
+			t.Error(context.GotExpString("Result", got, tc.exp))
+		}
+	}
+}
+
+func TestArchivePosts(t *testing.T) {
+	// newest first
+	posts := []*models.Post{{Filename: "5"}, {Filename: "4"}, {Filename: "3"}, {Filename: "2"}, {Filename: "1"}}
+
+	testCases := []struct {
+		number int
+		exp    []string
+	}{
+		{0, []string{}},
+		{1, []string{"2", "1"}},
+		{2, []string{"4", "3"}},
+		{3, []string{}},
+	}
+
+	for testCaseIndex, tc := range testCases {
+		context := test.NewContext().SetFields(test.ContextFields{
+			"index":  testCaseIndex,
+			"number": tc.number,
+		})
+
+		archivePosts := ArchivePosts(posts, tc.number, 2)
+		got := make([]string, len(archivePosts))
+		for i, post := range archivePosts {
+			got[i] = post.Filename
+		}
+		if !cmp.Equal(got, tc.exp) {
+			t.Error(context.GotExpString("Result", got, tc.exp))
+		}
+	}
+}
+
+func TestHistory_AddTo(t *testing.T) {
+	feed := `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>posts</title>
+</feed>`
+
+	testCases := []struct {
+		history *History
+		exp     string
+	}{
+		{&History{}, feed},
+		{&History{PrevArchive: "https://test.com/posts-archive-2.atom"}, `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <link rel="prev-archive" href="https://test.com/posts-archive-2.atom"/>
+  <title>posts</title>
+</feed>`},
+		{&History{true, "/posts.atom", "/posts-archive-1.atom", "/posts-archive-3.atom?a=1&b=2"}, `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <fh:archive xmlns:fh="http://purl.org/syndication/history/1.0"/>
+  <link rel="current" href="/posts.atom"/>
+  <link rel="prev-archive" href="/posts-archive-1.atom"/>
+  <link rel="next-archive" href="/posts-archive-3.atom?a=1&amp;b=2"/>
+  <title>posts</title>
+</feed>`},
+	}
+
+	for testCaseIndex, tc := range testCases {
+		context := test.NewContext().SetFields(test.ContextFields{
+			"index":   testCaseIndex,
+			"history": tc.history,
+		})
+
+		got, err := tc.history.AddTo([]byte(feed))
+		if err != nil {
+			t.Error(context.String(err))
+		}
+		if string(got) != tc.exp {
+			t.Error(context.GotExpString("Result", string(got), tc.exp))
+		}
+	}
+
+	_, err := (&History{Current: "/posts.atom"}).AddTo([]byte("not a feed"))
+	if err == nil {
+		t.Error("no error for missing feed element")
+	}
+}
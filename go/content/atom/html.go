@@ -0,0 +1,98 @@
+package atom
+
+import (
+	"bytes"
+	"io"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+var urlAttrs = map[string]bool{
+	"href": true,
+	"src":  true,
+}
+
+// ImageURL maps the relative src of an image to the URL it is served from, like the webpack manifest URL of a post image
+type ImageURL func(src string) string
+
+// AbsoluteHTML resolves the relative href, src and srcset attributes of htmlString against baseURL,
+// so feed readers can follow links and load images. The relative img src are mapped by imageURL first, if it is not nil
+func AbsoluteHTML(htmlString, baseURL string, imageURL ImageURL) string {
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return htmlString
+	}
+
+	var buffer bytes.Buffer
+	tokenizer := html.NewTokenizer(strings.NewReader(htmlString))
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			if tokenizer.Err() != io.EOF {
+				return htmlString
+			}
+			return buffer.String()
+		}
+
+		raw := string(tokenizer.Raw())
+		if tokenType != html.StartTagToken && tokenType != html.SelfClosingTagToken {
+			buffer.WriteString(raw)
+			continue
+		}
+
+		token := tokenizer.Token()
+		changed := false
+		for i, attr := range token.Attr {
+			var value string
+			switch {
+			case imageURL != nil && token.Data == "img" && attr.Key == "src" && isRelative(attr.Val):
+				value = resolve(base, imageURL(strings.TrimSpace(attr.Val)))
+			case urlAttrs[attr.Key]:
+				value = resolve(base, attr.Val)
+			case attr.Key == "srcset":
+				value = resolveSrcset(base, attr.Val)
+			default:
+				continue
+			}
+			if value != attr.Val {
+				token.Attr[i].Val = value
+				changed = true
+			}
+		}
+		if changed {
+			raw = token.String()
+		}
+		buffer.WriteString(raw)
+	}
+}
+
+func isRelative(href string) bool {
+	u, err := url.Parse(strings.TrimSpace(href))
+	return err == nil && !u.IsAbs() && u.Host == "" && (u.Path != "" || u.RawQuery != "")
+}
+
+func resolve(base *url.URL, href string) string {
+	if !isRelative(href) {
+		return href
+	}
+	u, err := url.Parse(strings.TrimSpace(href))
+	if err != nil {
+		return href
+	}
+	return base.ResolveReference(u).String()
+}
+
+func resolveSrcset(base *url.URL, srcset string) string {
+	candidates := strings.Split(srcset, ",")
+	for i, candidate := range candidates {
+		fields := strings.Fields(candidate)
+		if len(fields) == 0 {
+			continue
+		}
+		fields[0] = resolve(base, fields[0])
+		candidates[i] = strings.Join(fields, " ")
+	}
+	return strings.Join(candidates, ", ")
+}
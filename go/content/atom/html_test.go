@@ -0,0 +1,64 @@
+package atom
+
+import (
+	"testing"
+
+	"github.com/s12chung/gostatic/go/test"
+)
+
+func TestAbsoluteHTML(t *testing.T) {
+	baseURL := "https://test.com/some_post"
+	testCases := []struct {
+		html string
+		exp  string
+	}{
+		{"", ""},
+		{"<p>No links &amp; no images.</p>", "<p>No links &amp; no images.</p>"},
+		{`<img src="images/test.png" alt="Test"/>`, `<img src="https://test.com/images/test.png" alt="Test"/>`},
+		{`<a href="/about">About</a>`, `<a href="https://test.com/about">About</a>`},
+		{`<a href="../about?a=1&amp;b=2">About</a>`, `<a href="https://test.com/about?a=1&amp;b=2">About</a>`},
+		{`<a href="https://other.com/page">Other</a>`, `<a href="https://other.com/page">Other</a>`},
+		{`<a href="//other.com/page">Other</a>`, `<a href="//other.com/page">Other</a>`},
+		{`<a href="mailto:hello@test.com">Email</a>`, `<a href="mailto:hello@test.com">Email</a>`},
+		{`<a href="#top">Top</a>`, `<a href="#top">Top</a>`},
+		{`<img srcset="images/a.png 1x, images/b.png 2x">`, `<img srcset="https://test.com/images/a.png 1x, https://test.com/images/b.png 2x">`},
+		{"<pre><code>&lt;a href=&quot;/about&quot;&gt;</code></pre>", "<pre><code>&lt;a href=&quot;/about&quot;&gt;</code></pre>"},
+	}
+
+	for testCaseIndex, tc := range testCases {
+		context := test.NewContext().SetFields(test.ContextFields{
+			"index": testCaseIndex,
+			"html":  tc.html,
+		})
+
+		got := AbsoluteHTML(tc.html, baseURL, nil)
+		if got != tc.exp {
+			t.Error(context.GotExpString("Result", got, tc.exp))
+		}
+	}
+}
+
+func TestAbsoluteHTML_imageURL(t *testing.T) {
+	baseURL := "https://test.com/fr/some_post"
+	imageURL := func(src string) string { return "/assets/content/" + src }
+	testCases := []struct {
+		html string
+		exp  string
+	}{
+		{`<img src="images/test.png" alt="Test"/>`, `<img src="https://test.com/assets/content/images/test.png" alt="Test"/>`},
+		{`<img src="https://other.com/test.png"/>`, `<img src="https://other.com/test.png"/>`},
+		{`<a href="images/test.png">Test</a>`, `<a href="https://test.com/fr/images/test.png">Test</a>`},
+	}
+
+	for testCaseIndex, tc := range testCases {
+		context := test.NewContext().SetFields(test.ContextFields{
+			"index": testCaseIndex,
+			"html":  tc.html,
+		})
+
+		got := AbsoluteHTML(tc.html, baseURL, imageURL)
+		if got != tc.exp {
+			t.Error(context.GotExpString("Result", got, tc.exp))
+		}
+	}
+}
@@ -2,27 +2,30 @@ package atom
 
 import (
 	"github.com/s12chung/go_homepage/go/content/models"
+	"github.com/s12chung/go_homepage/go/content/site"
 
 	"github.com/s12chung/gostatic-packages/atom"
 )
 
-func PostsToHTMLEntries(posts []*models.Post) []*atom.HTMLEntry {
-	if atom.EntryLimit < len(posts) {
-		posts = posts[0:atom.EntryLimit]
+func PostsToHTMLEntries(posts []*models.Post, settings *site.Settings, imageURL ImageURL) []*atom.HTMLEntry {
+	if settings.FeedEntryLimit > 0 && settings.FeedEntryLimit < len(posts) {
+		posts = posts[0:settings.FeedEntryLimit]
 	}
 	htmlEntries := make([]*atom.HTMLEntry, len(posts))
 	for i, post := range posts {
-		htmlEntries[i] = PostToHTMLEntry(post)
+		htmlEntries[i] = PostToHTMLEntry(post, settings, imageURL)
 	}
 	return htmlEntries
 }
 
-func PostToHTMLEntry(post *models.Post) *atom.HTMLEntry {
+// PostToHTMLEntry maps the post images with imageURL, as the images are not served relative to the post
+func PostToHTMLEntry(post *models.Post, settings *site.Settings, imageURL ImageURL) *atom.HTMLEntry {
+	postURL := settings.AbsoluteURL(post.URL())
 	return &atom.HTMLEntry{
-		ID:          post.ID(),
+		ID:          postURL,
 		Title:       post.Title,
-		Updated:     post.PublishedAt,
-		HTMLContent: post.MarkdownHTML,
+		Updated:     post.LastUpdatedAt(),
+		HTMLContent: AbsoluteHTML(post.MarkdownHTML, postURL, imageURL),
 		Summary:     post.Description,
 		Published:   post.PublishedAt,
 	}
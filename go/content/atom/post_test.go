@@ -1,30 +1,41 @@
 package atom
 
 import (
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	logTest "github.com/sirupsen/logrus/hooks/test"
+
 	"github.com/s12chung/go_homepage/go/content/models"
+	"github.com/s12chung/go_homepage/go/content/site"
 	"github.com/s12chung/gostatic/go/test"
-	"testing"
 )
 
 func TestPostsToHTMLEntries(t *testing.T) {
 	testCases := []struct {
 		numberOfPosts int
+		entryLimit    int
 		expected      int
 	}{
-		{-1, 0},
-		{0, 0},
-		{1, 1},
-		{5, 5},
-		{99, 99},
-		{100, 100},
-		{101, 100},
-		{300, 100},
+		{-1, 100, 0},
+		{0, 100, 0},
+		{1, 100, 1},
+		{5, 100, 5},
+		{99, 100, 99},
+		{100, 100, 100},
+		{101, 100, 100},
+		{300, 100, 100},
+		{5, 2, 2},
+		{5, 0, 5},
 	}
 
 	for testCaseIndex, tc := range testCases {
 		context := test.NewContext().SetFields(test.ContextFields{
 			"index":         testCaseIndex,
 			"numberOfPosts": tc.numberOfPosts,
+			"entryLimit":    tc.entryLimit,
 		})
 
 		var posts []*models.Post
@@ -34,9 +45,66 @@ func TestPostsToHTMLEntries(t *testing.T) {
 		for i := 0; i < tc.numberOfPosts; i++ {
 			posts[i] = &models.Post{}
 		}
-		entries := PostsToHTMLEntries(posts)
+		settings := site.DefaultSettings()
+		settings.FeedEntryLimit = tc.entryLimit
+		entries := PostsToHTMLEntries(posts, settings, nil)
 		if len(entries) != tc.expected {
 			t.Error(context.GotExpString("len(entries)", len(entries), tc.expected))
 		}
 	}
 }
+
+func TestPostToHTMLEntry(t *testing.T) {
+	testCases := []struct {
+		siteURL string
+		exp     string
+	}{
+		{"", "/some_post"},
+		{"https://test.com", "https://test.com/some_post"},
+	}
+
+	for testCaseIndex, tc := range testCases {
+		context := test.NewContext().SetFields(test.ContextFields{
+			"index":   testCaseIndex,
+			"siteURL": tc.siteURL,
+		})
+
+		settings := site.DefaultSettings()
+		settings.URL = tc.siteURL
+		entry := PostToHTMLEntry(&models.Post{Filename: "some_post"}, settings, nil)
+		if entry.ID != tc.exp {
+			t.Error(context.GotExpString("entry.ID", entry.ID, tc.exp))
+		}
+	}
+}
+
+func TestPostToHTMLEntry_images(t *testing.T) {
+	contentPath := "../../../content"
+	log, _ := logTest.NewNullLogger()
+	models.TestConfig(contentPath, log)
+	defer models.TestConfig(path.Join("../models", test.FixturePath), log)
+
+	post, err := models.NewPost("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = os.Stat(path.Join(contentPath, "drafts", "images/test.png"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	settings := site.DefaultSettings()
+	settings.URL = "https://test.com"
+	imageURL := func(src string) string { return path.Join("/assets/content", src) }
+	for _, post := range []*models.Post{post, {Filename: "test.fr", Lang: "fr", MarkdownHTML: post.MarkdownHTML}} {
+		context := test.NewContext().SetFields(test.ContextFields{
+			"filename": post.Filename,
+		})
+
+		entry := PostToHTMLEntry(post, settings, imageURL)
+		exp := `src="https://test.com/assets/content/images/test.png"`
+		if !strings.Contains(entry.HTMLContent, exp) {
+			t.Error(context.GotExpString("HTMLContent", entry.HTMLContent, exp))
+		}
+	}
+}
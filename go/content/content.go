@@ -1,6 +1,7 @@
 package content
 
 import (
+	"fmt"
 	"mime"
 
 	"github.com/sirupsen/logrus"
@@ -20,6 +21,7 @@ var ExtraMimeTypes = map[string]string{
 	".atom": "application/xml",
 	".ico":  "image/x-icon",
 	".txt":  "text/plain; charset=utf-8",
+	".xml":  "application/xml",
 }
 
 type Content struct {
@@ -35,6 +37,7 @@ type Route interface {
 }
 
 func NewContent(generatedPath string, settings *Settings, log logrus.FieldLogger) *Content {
+	settings.FillDefaults()
 	models.Config(settings.Models, log.WithFields(logrus.Fields{
 		"type": "models",
 	}))
@@ -47,9 +50,9 @@ func NewContent(generatedPath string, settings *Settings, log logrus.FieldLogger
 
 	w := webpack.NewWebpack(generatedPath, settings.Webpack, log)
 	md := markdown.NewMarkdown(settings.Markdown, log)
-	htmlRenderer := html.NewRenderer(settings.HTML, []html.Plugin{w, md}, log)
+	htmlRenderer := html.NewRenderer(settings.HTML, []html.Plugin{w, md, settings.Site}, log)
 	atomRenderer := atom.NewHTMLRenderer(settings.Atom)
-	helper := routes.NewBaseHelper(settings.Goodreads, w, htmlRenderer, atomRenderer)
+	helper := routes.NewBaseHelper(settings.Site, settings.Groups, settings.Nav, settings.Reading, settings.Goodreads, settings.Atom, settings.Webmention, w, htmlRenderer, atomRenderer, log)
 
 	return &Content{
 		settings,
@@ -59,13 +62,34 @@ func NewContent(generatedPath string, settings *Settings, log logrus.FieldLogger
 	}
 }
 
+// allRoutes are the route groups enabled in the groups settings, they share the handlers, so the books are loaded once
 func allRoutes(helper routes.Helper) []Route {
-	return []Route{
-		routes.NewAllRoutes(helper),
+	settings := helper.GroupsSettings()
+	handlers := routes.NewAllRoutes(helper)
+	var all []Route
+	if settings.Posts {
+		all = append(all, routes.NewPostsRoutes(handlers))
 	}
+	if settings.Feeds {
+		all = append(all, routes.NewFeedsRoutes(handlers))
+	}
+	if settings.Reading {
+		all = append(all, routes.NewReadingRoutes(handlers))
+	}
+	if settings.Pages {
+		all = append(all, routes.NewPagesRoutes(handlers))
+	}
+	if settings.System {
+		all = append(all, routes.NewSystemRoutes(handlers))
+	}
+	return all
 }
 
+// SetRoutes fails without a site URL, as canonical links, structured data and feeds need absolute URLs
 func (content *Content) SetRoutes(r router.Router, tracker *app.Tracker) error {
+	if content.Settings.Site.URL == "" {
+		return fmt.Errorf("the site url setting, or the atom host setting, is needed for absolute URLs")
+	}
 	for _, route := range content.routes {
 		err := route.SetRoutes(r, tracker)
 		if err != nil {
@@ -75,6 +99,18 @@ func (content *Content) SetRoutes(r router.Router, tracker *app.Tracker) error {
 	return nil
 }
 
+func (content *Content) URLs() ([]string, error) {
+	r := router.NewGenerateRouter(content.Log)
+	tracker := app.NewTracker(func() []string {
+		return nil
+	})
+	err := content.SetRoutes(r, tracker)
+	if err != nil {
+		return nil, err
+	}
+	return r.URLs(), nil
+}
+
 func (content *Content) AssetsURL() string {
 	return content.helper.Webpack.AssetsURL()
 }
@@ -1,6 +1,7 @@
 package content
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/sirupsen/logrus"
@@ -11,11 +12,15 @@ import (
 	"github.com/s12chung/gostatic/go/lib/router"
 	"github.com/s12chung/gostatic/go/test"
 	"sort"
+
+	"github.com/s12chung/go_homepage/go/content/routes"
 )
 
 func defaultContent() (*Content, logrus.FieldLogger, *logTest.Hook) {
 	log, hook := logTest.NewNullLogger()
-	return NewContent("", DefaultSettings(), log), log, hook
+	settings := DefaultSettings()
+	settings.Atom.Host = "test.com"
+	return NewContent("", settings, log), log, hook
 }
 
 var handler = func(ctx router.Context) error {
@@ -107,3 +112,61 @@ func TestContent_SetRoutes(t *testing.T) {
 		}
 	}
 }
+
+func TestContent_URLs(t *testing.T) {
+	content, _, _ := defaultContent()
+	content.routes = []Route{&routeOne{}, &routeTwo{}}
+
+	got, err := content.URLs()
+	if err != nil {
+		t.Error(err)
+	}
+	exp := []string{"/", "/about", "/posts", "/robots.txt", "/something", "/posts.atom"}
+	sort.Strings(got)
+	sort.Strings(exp)
+	if !cmp.Equal(got, exp) {
+		t.Error(test.NewContext().DiffString("content.URLs()", got, exp, cmp.Diff(got, exp)))
+	}
+}
+
+func TestNewContent_siteURL(t *testing.T) {
+	content, _, _ := defaultContent()
+	test.AssertLabel(t, "Site.URL", content.Settings.Site.URL, "https://test.com")
+
+	content.Settings.Site.URL = ""
+	_, err := content.URLs()
+	if err == nil {
+		t.Error("no error without a site url")
+	}
+}
+
+func TestAllRoutes(t *testing.T) {
+	testCases := []struct {
+		reading bool
+		exp     []string
+	}{
+		{true, []string{"*routes.PostsRoutes", "*routes.FeedsRoutes", "*routes.ReadingRoutes", "*routes.PagesRoutes", "*routes.SystemRoutes"}},
+		{false, []string{"*routes.PostsRoutes", "*routes.FeedsRoutes", "*routes.PagesRoutes", "*routes.SystemRoutes"}},
+	}
+
+	for testCaseIndex, tc := range testCases {
+		context := test.NewContext().SetFields(test.ContextFields{
+			"index":   testCaseIndex,
+			"reading": tc.reading,
+		})
+
+		log, _ := logTest.NewNullLogger()
+		settings := DefaultSettings()
+		settings.Groups.Reading = tc.reading
+		helper := routes.NewBaseHelper(settings.Site, settings.Groups, settings.Nav, settings.Reading, settings.Goodreads, settings.Atom, settings.Webmention, nil, nil, nil, log)
+
+		all := allRoutes(helper)
+		got := make([]string, len(all))
+		for i, route := range all {
+			got[i] = fmt.Sprintf("%T", route)
+		}
+		if !cmp.Equal(got, tc.exp) {
+			t.Error(context.DiffString("Result", got, tc.exp, cmp.Diff(got, tc.exp)))
+		}
+	}
+}
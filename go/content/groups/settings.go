@@ -0,0 +1,25 @@
+package groups
+
+// Settings toggle the route groups, so a site can be built without /reading for example
+type Settings struct {
+	// Posts are the home page, posts, translations and archive pages
+	Posts bool `json:"posts"`
+	// Feeds are the atom feeds of posts
+	Feeds bool `json:"feeds"`
+	// Reading is /reading with its books, shelves, stats, highlights and atom feed
+	Reading bool `json:"reading"`
+	// Pages are the markdown pages
+	Pages bool `json:"pages"`
+	// System are robots.txt, sitemap.xml and 404.html
+	System bool `json:"system"`
+}
+
+func DefaultSettings() *Settings {
+	return &Settings{
+		true,
+		true,
+		true,
+		true,
+		true,
+	}
+}
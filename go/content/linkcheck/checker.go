@@ -0,0 +1,281 @@
+package linkcheck
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/html"
+)
+
+const (
+	DeadLink      = "dead link"
+	MissingImage  = "missing image"
+	MissingAnchor = "missing anchor"
+)
+
+const indexFilename = "index.html"
+
+type Problem struct {
+	PageURL string
+	Link    string
+	Kind    string
+}
+
+func (problem *Problem) String() string {
+	return fmt.Sprintf("%v: %v - %v", problem.PageURL, problem.Kind, problem.Link)
+}
+
+type Checker struct {
+	generatedPath string
+	assetsURL     string
+	assetsPath    string
+	urls          map[string]bool
+	log           logrus.FieldLogger
+
+	pageIDs map[string]map[string]bool
+}
+
+func NewChecker(generatedPath string, urls []string, assetsURL, generatedAssetsPath string, log logrus.FieldLogger) *Checker {
+	urlMap := map[string]bool{}
+	for _, u := range urls {
+		urlMap[u] = true
+	}
+	return &Checker{
+		generatedPath,
+		assetsURL,
+		generatedAssetsPath,
+		urlMap,
+		log,
+		map[string]map[string]bool{},
+	}
+}
+
+type link struct {
+	url     string
+	isImage bool
+}
+
+type page struct {
+	links []link
+	ids   map[string]bool
+}
+
+func (checker *Checker) Check() ([]*Problem, error) {
+	pageURLs, err := checker.htmlPageURLs()
+	if err != nil {
+		return nil, err
+	}
+
+	var problems []*Problem
+	for _, pageURL := range pageURLs {
+		checker.log.Infof("Checking links of %v", pageURL)
+
+		p, err := checker.page(pageURL)
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range p.links {
+			problem, err := checker.checkLink(pageURL, l)
+			if err != nil {
+				return nil, err
+			}
+			if problem != nil {
+				problems = append(problems, problem)
+			}
+		}
+	}
+	return problems, nil
+}
+
+func (checker *Checker) checkLink(pageURL string, l link) (*Problem, error) {
+	u, err := resolve(pageURL, l.url)
+	if err != nil {
+		return &Problem{pageURL, l.url, DeadLink}, nil
+	}
+	if u == nil {
+		return nil, nil
+	}
+
+	if !checker.exists(u.Path) {
+		kind := DeadLink
+		if l.isImage {
+			kind = MissingImage
+		}
+		return &Problem{pageURL, l.url, kind}, nil
+	}
+
+	if u.Fragment == "" || !checker.urls[u.Path] {
+		return nil, nil
+	}
+	ids, err := checker.ids(u.Path)
+	if err != nil {
+		return nil, err
+	}
+	if !ids[u.Fragment] {
+		return &Problem{pageURL, l.url, MissingAnchor}, nil
+	}
+	return nil, nil
+}
+
+func (checker *Checker) exists(urlPath string) bool {
+	if checker.urls[urlPath] {
+		return true
+	}
+	if checker.assetsURL != "" && strings.HasPrefix(urlPath, checker.assetsURL) {
+		return fileExists(filepath.Join(checker.assetsPath, strings.TrimPrefix(urlPath, checker.assetsURL)))
+	}
+	return fileExists(filepath.Join(checker.generatedPath, urlPath))
+}
+
+func (checker *Checker) ids(pageURL string) (map[string]bool, error) {
+	ids, exists := checker.pageIDs[pageURL]
+	if exists {
+		return ids, nil
+	}
+	p, err := checker.page(pageURL)
+	if err != nil {
+		return nil, err
+	}
+	return p.ids, nil
+}
+
+func (checker *Checker) page(pageURL string) (*page, error) {
+	bytes, err := ioutil.ReadFile(checker.pageFilePath(pageURL))
+	if err != nil {
+		return nil, err
+	}
+	p, err := parsePage(string(bytes))
+	if err != nil {
+		return nil, err
+	}
+	checker.pageIDs[pageURL] = p.ids
+	return p, nil
+}
+
+func (checker *Checker) pageFilePath(pageURL string) string {
+	if pageURL == "/" {
+		return filepath.Join(checker.generatedPath, indexFilename)
+	}
+	filePath := filepath.Join(checker.generatedPath, pageURL)
+	if !fileExists(filePath) && fileExists(filePath+".html") {
+		return filePath + ".html"
+	}
+	return filePath
+}
+
+func (checker *Checker) htmlPageURLs() ([]string, error) {
+	var pageURLs []string
+	err := filepath.Walk(checker.generatedPath, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if checker.assetsPath != "" && filepath.Clean(filePath) == filepath.Clean(checker.assetsPath) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		ext := filepath.Ext(filePath)
+		if ext != "" && ext != ".html" {
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(checker.generatedPath, filePath)
+		if err != nil {
+			return err
+		}
+		pageURL := "/" + filepath.ToSlash(relativePath)
+		if relativePath == indexFilename {
+			pageURL = "/"
+		}
+		pageURLs = append(pageURLs, pageURL)
+		return nil
+	})
+	sort.Strings(pageURLs)
+	return pageURLs, err
+}
+
+func parsePage(s string) (*page, error) {
+	p := &page{ids: map[string]bool{}}
+
+	tokenizer := html.NewTokenizer(strings.NewReader(s))
+	for {
+		tokenType := tokenizer.Next()
+		switch tokenType {
+		case html.ErrorToken:
+			if tokenizer.Err() == io.EOF {
+				return p, nil
+			}
+			return nil, tokenizer.Err()
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+			for _, attr := range token.Attr {
+				switch attr.Key {
+				case "id":
+					p.ids[attr.Val] = true
+				case "name":
+					if token.Data == "a" {
+						p.ids[attr.Val] = true
+					}
+				case "href":
+					p.links = append(p.links, link{attr.Val, false})
+				case "src":
+					p.links = append(p.links, link{attr.Val, token.Data == "img"})
+				case "srcset":
+					for _, src := range srcsetURLs(attr.Val) {
+						p.links = append(p.links, link{src, true})
+					}
+				}
+			}
+		}
+	}
+}
+
+func srcsetURLs(srcset string) []string {
+	var urls []string
+	for _, candidate := range strings.Split(srcset, ",") {
+		fields := strings.Fields(candidate)
+		if len(fields) > 0 {
+			urls = append(urls, fields[0])
+		}
+	}
+	return urls
+}
+
+// resolve returns nil for links outside of the site, such as external and mailto links
+func resolve(pageURL, href string) (*url.URL, error) {
+	href = strings.TrimSpace(href)
+	if href == "" {
+		return nil, nil
+	}
+	u, err := url.Parse(href)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "" || u.Host != "" {
+		return nil, nil
+	}
+
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return nil, err
+	}
+	u = base.ResolveReference(u)
+	if u.Path != "/" {
+		u.Path = strings.TrimSuffix(path.Clean(u.Path), "/")
+	}
+	return u, nil
+}
+
+func fileExists(filePath string) bool {
+	info, err := os.Stat(filePath)
+	return err == nil && !info.IsDir()
+}
@@ -0,0 +1,86 @@
+package linkcheck
+
+import (
+	"path"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+
+	"github.com/s12chung/gostatic/go/test"
+)
+
+var generatedPath = path.Join(test.FixturePath, "generated")
+
+func defaultChecker() *Checker {
+	log, _ := logTest.NewNullLogger()
+	urls := []string{"/", "/about", "/post", "/robots.txt", "/test"}
+	return NewChecker(generatedPath, urls, "/assets/", path.Join(generatedPath, "assets"), log)
+}
+
+func TestChecker_Check(t *testing.T) {
+	problems, err := defaultChecker().Check()
+	if err != nil {
+		t.Error(err)
+	}
+
+	exp := []*Problem{
+		{"/about", "/does-not-exist", DeadLink},
+		{"/about", "/#missing", MissingAnchor},
+		{"/about", "/assets/content/images/test-2x.png", MissingImage},
+		{"/post", "images/test.jpg", MissingImage},
+		{"/post", "/assets/missing.js", DeadLink},
+		// the test draft, drafts are checked like the other pages
+		{"/test", "images/test.jpg", MissingImage},
+	}
+	if !cmp.Equal(problems, exp) {
+		t.Error(test.NewContext().DiffString("problems", problems, exp, cmp.Diff(problems, exp)))
+	}
+}
+
+func TestResolve(t *testing.T) {
+	testCases := []struct {
+		pageURL  string
+		href     string
+		internal bool
+		path     string
+		fragment string
+	}{
+		{"/", "", false, "", ""},
+		{"/", "https://www.google.com", false, "", ""},
+		{"/", "//www.google.com", false, "", ""},
+		{"/", "mailto:hello@example.com", false, "", ""},
+		{"/", "/about", true, "/about", ""},
+		{"/", "/about/", true, "/about", ""},
+		{"/", "#top", true, "/", "top"},
+		{"/post", "images/test.png", true, "/images/test.png", ""},
+		{"/posts/post", "../about#contact", true, "/about", "contact"},
+	}
+
+	for testCaseIndex, tc := range testCases {
+		context := test.NewContext().SetFields(test.ContextFields{
+			"index":   testCaseIndex,
+			"pageURL": tc.pageURL,
+			"href":    tc.href,
+		})
+
+		u, err := resolve(tc.pageURL, tc.href)
+		if err != nil {
+			t.Error(context.String(err))
+			continue
+		}
+		if (u != nil) != tc.internal {
+			t.Error(context.GotExpString("internal", u != nil, tc.internal))
+			continue
+		}
+		if u == nil {
+			continue
+		}
+		if u.Path != tc.path {
+			t.Error(context.GotExpString("u.Path", u.Path, tc.path))
+		}
+		if u.Fragment != tc.fragment {
+			t.Error(context.GotExpString("u.Fragment", u.Fragment, tc.fragment))
+		}
+	}
+}
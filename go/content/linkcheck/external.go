@@ -0,0 +1,242 @@
+package linkcheck
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const cacheFilename = "external.json"
+
+type Source struct {
+	Name string
+	HTML string
+}
+
+type Result struct {
+	URL        string    `json:"url"`
+	StatusCode int       `json:"status_code,omitempty"`
+	Timeout    bool      `json:"timeout,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	CheckedAt  time.Time `json:"checked_at"`
+}
+
+func (result *Result) Broken() bool {
+	return result.Timeout || result.Error != "" || result.StatusCode >= 400
+}
+
+func (result *Result) String() string {
+	switch {
+	case result.Timeout:
+		return fmt.Sprintf("timeout - %v", result.URL)
+	case result.Error != "":
+		return fmt.Sprintf("error (%v) - %v", result.Error, result.URL)
+	}
+	return fmt.Sprintf("%v - %v", result.StatusCode, result.URL)
+}
+
+type SourceReport struct {
+	Name    string
+	Results []*Result
+}
+
+type Report []*SourceReport
+
+func (report Report) String() string {
+	lines := make([]string, 0, len(report))
+	for _, sourceReport := range report {
+		lines = append(lines, sourceReport.Name)
+		for _, result := range sourceReport.Results {
+			lines = append(lines, "    "+result.String())
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+type ExternalChecker struct {
+	settings *Settings
+	client   *http.Client
+	log      logrus.FieldLogger
+}
+
+func NewHTTPClient(settings *Settings) *http.Client {
+	return &http.Client{Timeout: time.Duration(settings.TimeoutSeconds) * time.Second}
+}
+
+func NewExternalChecker(settings *Settings, client *http.Client, log logrus.FieldLogger) *ExternalChecker {
+	return &ExternalChecker{settings, client, log}
+}
+
+// Check returns a Report of the broken external links of each source, in the order of sources
+func (checker *ExternalChecker) Check(sources []*Source) (Report, error) {
+	cache, err := checker.readCache()
+	if err != nil {
+		return nil, err
+	}
+
+	var report Report
+	checked := map[string]*Result{}
+	for _, source := range sources {
+		links, err := ExternalLinks(source.HTML)
+		if err != nil {
+			return nil, err
+		}
+
+		sourceReport := &SourceReport{Name: source.Name}
+		for _, link := range links {
+			result := checker.result(cache, checked, link)
+			if result.Broken() {
+				sourceReport.Results = append(sourceReport.Results, result)
+			}
+		}
+		if len(sourceReport.Results) != 0 {
+			report = append(report, sourceReport)
+		}
+	}
+	return report, checker.writeCache(cache)
+}
+
+// result returns the result of the link, checking it once per run unless it is cached.
+// Only working links are cached, so broken links are checked again on the next run
+func (checker *ExternalChecker) result(cache, checked map[string]*Result, link string) *Result {
+	result := checked[link]
+	if result != nil {
+		return result
+	}
+	result = cache[link]
+	if result == nil || result.Broken() || checker.expired(result) {
+		result = checker.checkURL(link)
+	}
+	checked[link] = result
+
+	if result.Broken() {
+		delete(cache, link)
+	} else {
+		cache[link] = result
+	}
+	return result
+}
+
+func (checker *ExternalChecker) checkURL(u string) *Result {
+	checker.log.Infof("Checking external link %v", u)
+
+	result := checker.request(http.MethodHead, u)
+	// some servers don't support HEAD requests properly
+	if result.Broken() && !result.Timeout {
+		result = checker.request(http.MethodGet, u)
+	}
+	return result
+}
+
+func (checker *ExternalChecker) request(method, u string) *Result {
+	result := &Result{URL: u, CheckedAt: time.Now()}
+
+	request, err := http.NewRequest(method, u, nil)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	response, err := checker.client.Do(request)
+	if err != nil {
+		result.Timeout = isTimeout(err)
+		if !result.Timeout {
+			result.Error = err.Error()
+		}
+		return result
+	}
+	defer func() {
+		err := response.Body.Close()
+		if err != nil {
+			checker.log.Error(err)
+		}
+	}()
+	result.StatusCode = response.StatusCode
+	return result
+}
+
+func (checker *ExternalChecker) expired(result *Result) bool {
+	return time.Since(result.CheckedAt) > time.Duration(checker.settings.CacheHours)*time.Hour
+}
+
+func (checker *ExternalChecker) cacheFilePath() string {
+	return filepath.Join(checker.settings.CachePath, cacheFilename)
+}
+
+func (checker *ExternalChecker) readCache() (map[string]*Result, error) {
+	cache := map[string]*Result{}
+
+	bytes, err := ioutil.ReadFile(checker.cacheFilePath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cache, nil
+		}
+		return nil, err
+	}
+
+	var results []*Result
+	err = json.Unmarshal(bytes, &results)
+	if err != nil {
+		return nil, err
+	}
+	for _, result := range results {
+		cache[result.URL] = result
+	}
+	return cache, nil
+}
+
+func (checker *ExternalChecker) writeCache(cache map[string]*Result) error {
+	results := make([]*Result, 0, len(cache))
+	for _, result := range cache {
+		results = append(results, result)
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].URL < results[j].URL })
+
+	bytes, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(checker.settings.CachePath, 0755)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(checker.cacheFilePath(), bytes, 0644)
+}
+
+// ExternalLinks returns the unique http and https links of the HTML, in order of appearance
+func ExternalLinks(htmlString string) ([]string, error) {
+	p, err := parsePage(htmlString)
+	if err != nil {
+		return nil, err
+	}
+
+	var links []string
+	seen := map[string]bool{}
+	for _, l := range p.links {
+		u, err := url.Parse(strings.TrimSpace(l.url))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			continue
+		}
+		u.Fragment = ""
+		link := u.String()
+		if !seen[link] {
+			seen[link] = true
+			links = append(links, link)
+		}
+	}
+	return links, nil
+}
+
+func isTimeout(err error) bool {
+	netErr, ok := err.(net.Error)
+	return ok && netErr.Timeout()
+}
@@ -0,0 +1,142 @@
+package linkcheck
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+
+	"github.com/s12chung/gostatic/go/test"
+)
+
+func testServer(requestCount *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requestCount++
+		switch r.URL.Path {
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		case "/error":
+			w.WriteHeader(http.StatusInternalServerError)
+		case "/no_head":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		case "/slow":
+			time.Sleep(100 * time.Millisecond)
+		}
+	}))
+}
+
+func externalChecker(t *testing.T) (*ExternalChecker, func()) {
+	log, _ := logTest.NewNullLogger()
+	settings := DefaultSettings()
+	cachePath, clean := test.SandboxDir(t, settings.CachePath)
+	settings.CachePath = cachePath
+
+	client := NewHTTPClient(settings)
+	client.Timeout = 50 * time.Millisecond
+	return NewExternalChecker(settings, client, log), clean
+}
+
+func resultStrings(report Report) map[string][]string {
+	strings := map[string][]string{}
+	for _, sourceReport := range report {
+		for _, result := range sourceReport.Results {
+			strings[sourceReport.Name] = append(strings[sourceReport.Name], result.String())
+		}
+	}
+	return strings
+}
+
+func TestExternalChecker_Check(t *testing.T) {
+	requestCount := 0
+	server := testServer(&requestCount)
+	defer server.Close()
+
+	checker, clean := externalChecker(t)
+	defer clean()
+
+	link := func(p string) string { return fmt.Sprintf(`<a href="%v%v">link</a>`, server.URL, p) }
+	sources := []*Source{
+		{"good", link("/ok") + link("/no_head") + `<a href="/about">internal</a>`},
+		{"bad", link("/missing") + link("/error") + link("/slow") + link("/ok")},
+		{"repeat", link("/missing#anchor")},
+	}
+
+	exp := map[string][]string{
+		"bad": {
+			"404 - " + server.URL + "/missing",
+			"500 - " + server.URL + "/error",
+			"timeout - " + server.URL + "/slow",
+		},
+		"repeat": {
+			"404 - " + server.URL + "/missing",
+		},
+	}
+	for i := 0; i < 2; i++ {
+		context := test.NewContext().SetFields(test.ContextFields{
+			"run": i,
+		})
+
+		report, err := checker.Check(sources)
+		if err != nil {
+			t.Error(context.String(err))
+		}
+		got := resultStrings(report)
+		if !cmp.Equal(got, exp) {
+			t.Error(context.DiffString("resultStrings(report)", got, exp, cmp.Diff(got, exp)))
+		}
+	}
+
+	// HEAD then GET for /no_head, /missing and /error, HEAD for /ok, /slow times out once,
+	// the broken links are not cached, so /missing, /error and /slow are checked again
+	expRequestCount := 13
+	if requestCount != expRequestCount {
+		t.Error(test.NewContext().GotExpString("requestCount", requestCount, expRequestCount))
+	}
+
+	cache, err := checker.readCache()
+	if err != nil {
+		t.Error(err)
+	}
+	cachedLinks := make([]string, 0, len(cache))
+	for link := range cache {
+		cachedLinks = append(cachedLinks, link)
+	}
+	sort.Strings(cachedLinks)
+	expCachedLinks := []string{server.URL + "/no_head", server.URL + "/ok"}
+	if !cmp.Equal(cachedLinks, expCachedLinks) {
+		t.Error(test.NewContext().DiffString("cachedLinks", cachedLinks, expCachedLinks, cmp.Diff(cachedLinks, expCachedLinks)))
+	}
+}
+
+func TestExternalLinks(t *testing.T) {
+	testCases := []struct {
+		html string
+		exp  []string
+	}{
+		{"", nil},
+		{`<a href="/about">About</a><a href="#top">Top</a><a href="mailto:hello@example.com">Email</a>`, nil},
+		{`<a href="http://a.com">A</a><img src="https://b.com/image.png"><a href="http://a.com#top">A</a>`, []string{"http://a.com", "https://b.com/image.png"}},
+	}
+
+	for testCaseIndex, tc := range testCases {
+		context := test.NewContext().SetFields(test.ContextFields{
+			"index": testCaseIndex,
+			"html":  tc.html,
+		})
+
+		got, err := ExternalLinks(tc.html)
+		if err != nil {
+			t.Error(context.String(err))
+		}
+		if !cmp.Equal(got, tc.exp) {
+			t.Error(context.DiffString("Result", got, tc.exp, cmp.Diff(got, tc.exp)))
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package linkcheck
+
+type Settings struct {
+	CachePath      string `json:"cache_path,omitempty"`
+	CacheHours     int    `json:"cache_hours,omitempty"`
+	TimeoutSeconds int    `json:"timeout_seconds,omitempty"`
+	MarkdownsPath  string `json:"markdowns_path,omitempty"`
+}
+
+func DefaultSettings() *Settings {
+	return &Settings{
+		"./cache/linkcheck",
+		24 * 7,
+		10,
+		"./content/markdowns",
+	}
+}
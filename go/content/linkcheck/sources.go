@@ -0,0 +1,48 @@
+package linkcheck
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/russross/blackfriday"
+
+	"github.com/s12chung/go_homepage/go/content/models"
+)
+
+// PostSources are the published posts, newest first
+func PostSources() ([]*Source, error) {
+	posts, err := models.Posts()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(posts, func(i, j int) bool { return posts[i].PublishedAt.After(posts[j].PublishedAt) })
+
+	sources := make([]*Source, len(posts))
+	for i, post := range posts {
+		sources[i] = &Source{post.Filename, post.MarkdownHTML}
+	}
+	return sources, nil
+}
+
+// MarkdownSources are the markdown files of markdownsPath without their front matter, sorted by name
+func MarkdownSources(markdownsPath string) ([]*Source, error) {
+	filePaths, err := filepath.Glob(filepath.Join(markdownsPath, "*.md"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(filePaths)
+
+	sources := make([]*Source, len(filePaths))
+	for i, filePath := range filePaths {
+		bytes, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			return nil, err
+		}
+		name := strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath))
+		markdown := models.WithoutFrontMatter(string(bytes))
+		sources[i] = &Source{name, string(blackfriday.Run([]byte(markdown)))}
+	}
+	return sources, nil
+}
@@ -0,0 +1,59 @@
+package linkcheck
+
+import (
+	"path"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+
+	"github.com/s12chung/gostatic/go/test"
+
+	"github.com/s12chung/go_homepage/go/content/models"
+)
+
+var modelsFixturePath = path.Join("../models", test.FixturePath)
+
+func TestPostSources(t *testing.T) {
+	log, _ := logTest.NewNullLogger()
+	models.TestConfig(modelsFixturePath, log)
+
+	sources, err := PostSources()
+	if err != nil {
+		t.Error(err)
+	}
+	got := make([]string, len(sources))
+	for i, source := range sources {
+		got[i] = source.Name
+	}
+	sort.Strings(got)
+	exp := []string{"post1", "post1.fr", "post2"}
+	if !cmp.Equal(got, exp) {
+		t.Error(test.NewContext().DiffString("names", got, exp, cmp.Diff(got, exp)))
+	}
+}
+
+func TestMarkdownSources(t *testing.T) {
+	sources, err := MarkdownSources(path.Join(modelsFixturePath, "markdowns"))
+	if err != nil {
+		t.Error(err)
+	}
+
+	got := map[string]string{}
+	for _, source := range sources {
+		got[source.Name] = source.HTML
+		if strings.Contains(source.HTML, "title:") {
+			t.Error(test.NewContext().String("front matter in " + source.Name))
+		}
+	}
+	for name, exp := range map[string]string{
+		"now":     "<p>Reading.</p>\n",
+		"partial": "<p>A partial, included by a template.</p>\n",
+	} {
+		if got[name] != exp {
+			t.Error(test.NewContext().GotExpString(name, got[name], exp))
+		}
+	}
+}
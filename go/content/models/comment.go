@@ -0,0 +1,183 @@
+package models
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/russross/blackfriday"
+	"gopkg.in/yaml.v2"
+)
+
+const yamlExtension = ".yml"
+
+// commentHTMLFlags skip the HTML and the links to untrusted protocols (like javascript:) in comments, they are written by readers
+const commentHTMLFlags = blackfriday.CommonHTMLFlags | blackfriday.SkipHTML | blackfriday.Safelink
+
+var commentsMap = map[string][]*Comment{}
+
+func ResetCommentsMap() { commentsMap = map[string][]*Comment{} }
+
+// Comment is a file in CommentsPath/<post filename>/, either YAML with a body or markdown with front matter
+type Comment struct {
+	Author string    `yaml:"author"`
+	URL    string    `yaml:"url"` // the author's website, optional
+	Date   time.Time `yaml:"date"`
+	// ReplyTo is the ID of the comment replied to
+	ReplyTo string `yaml:"reply_to"`
+	Body    string `yaml:"body"`
+
+	ID       string     `yaml:"-"`
+	BodyHTML string     `yaml:"-"`
+	Replies  []*Comment `yaml:"-"`
+}
+
+func (comment *Comment) validate() error {
+	var missing []string
+	if comment.Author == "" {
+		missing = append(missing, "author")
+	}
+	if comment.Date.IsZero() {
+		missing = append(missing, "date")
+	}
+	if strings.TrimSpace(comment.Body) == "" {
+		missing = append(missing, "body")
+	}
+	if len(missing) != 0 {
+		return fmt.Errorf("missing %v", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// Count is the comment and its replies
+func (comment *Comment) Count() int {
+	count := 1
+	for _, reply := range comment.Replies {
+		count += reply.Count()
+	}
+	return count
+}
+
+// Comments returns the threaded comments of the post, sorted by date
+func Comments(post *Post) ([]*Comment, error) {
+	comments, exists := commentsMap[post.Filename]
+	if exists {
+		return comments, nil
+	}
+
+	dirPath := path.Join(factory.settings.CommentsPath, post.Filename)
+	infos, err := ioutil.ReadDir(dirPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			commentsMap[post.Filename] = nil
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var all []*Comment
+	for _, info := range infos {
+		ext := filepath.Ext(info.Name())
+		if info.IsDir() || (ext != yamlExtension && ext != markdownExtension) {
+			continue
+		}
+		filePath := path.Join(dirPath, info.Name())
+		comment, err := newComment(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("comment %v: %v", filePath, err)
+		}
+		comment.ID = strings.TrimSuffix(info.Name(), ext)
+		all = append(all, comment)
+	}
+
+	comments, err = threadComments(all)
+	if err != nil {
+		return nil, fmt.Errorf("comments of post %v: %v", post.Filename, err)
+	}
+	commentsMap[post.Filename] = comments
+	return comments, nil
+}
+
+// CommentCount is the number of comments of the post, including replies
+func CommentCount(post *Post) (int, error) {
+	comments, err := Comments(post)
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, comment := range comments {
+		count += comment.Count()
+	}
+	return count, nil
+}
+
+func newComment(filePath string) (*Comment, error) {
+	bytes, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	comment := Comment{}
+	if filepath.Ext(filePath) == markdownExtension {
+		frontMatter, markdown, err := splitFrontMatter(string(bytes))
+		if err != nil {
+			return nil, err
+		}
+		bytes = []byte(frontMatter)
+		comment.Body = markdown
+	}
+	err = yaml.Unmarshal(bytes, &comment)
+	if err != nil {
+		return nil, err
+	}
+
+	err = comment.validate()
+	if err != nil {
+		return nil, err
+	}
+	comment.BodyHTML = commentBodyHTML(comment.Body)
+	return &comment, nil
+}
+
+func commentBodyHTML(body string) string {
+	renderer := blackfriday.NewHTMLRenderer(blackfriday.HTMLRendererParameters{Flags: commentHTMLFlags})
+	return string(blackfriday.Run([]byte(body), blackfriday.WithRenderer(renderer)))
+}
+
+// threadComments nests the replies under their comments
+func threadComments(all []*Comment) ([]*Comment, error) {
+	sort.SliceStable(all, func(i, j int) bool { return all[i].Date.Before(all[j].Date) })
+
+	commentMap := map[string]*Comment{}
+	for _, comment := range all {
+		commentMap[comment.ID] = comment
+	}
+
+	var comments []*Comment
+	for _, comment := range all {
+		if comment.ReplyTo == "" {
+			comments = append(comments, comment)
+			continue
+		}
+		parent, exists := commentMap[comment.ReplyTo]
+		if !exists {
+			return nil, fmt.Errorf("comment %v replies to %v, which does not exist", comment.ID, comment.ReplyTo)
+		}
+		parent.Replies = append(parent.Replies, comment)
+	}
+
+	// replies in a cycle are not under any comment
+	count := 0
+	for _, comment := range comments {
+		count += comment.Count()
+	}
+	if count != len(all) {
+		return nil, fmt.Errorf("replies in a cycle, %v of %v comments are threaded", count, len(all))
+	}
+	return comments, nil
+}
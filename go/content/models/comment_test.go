@@ -0,0 +1,147 @@
+package models
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+
+	"github.com/s12chung/gostatic/go/test"
+)
+
+func TestComments(t *testing.T) {
+	configFactory()
+
+	post := &Post{Filename: "post1"}
+	got, err := Comments(post)
+	if err != nil {
+		t.Error(err)
+	}
+
+	bob := &Comment{
+		Author:   "Bob",
+		Date:     time.Date(2017, 8, 6, 10, 0, 0, 0, time.UTC),
+		ReplyTo:  "1-alice",
+		Body:     "Thanks, Alice.",
+		ID:       "2-bob",
+		BodyHTML: "<p>Thanks, Alice.</p>\n",
+	}
+	exp := []*Comment{
+		{
+			Author:   "Alice",
+			URL:      "https://alice.test",
+			Date:     time.Date(2017, 8, 5, 10, 0, 0, 0, time.UTC),
+			Body:     "Great **post**. <script>alert(1)</script>",
+			ID:       "1-alice",
+			BodyHTML: "<p>Great <strong>post</strong>. alert(1)</p>\n",
+			Replies:  []*Comment{bob},
+		},
+		{
+			Author:   "Carol",
+			Date:     time.Date(2017, 8, 7, 10, 0, 0, 0, time.UTC),
+			Body:     "A later comment.",
+			ID:       "0-carol",
+			BodyHTML: "<p>A later comment.</p>\n",
+		},
+	}
+	if !cmp.Equal(got, exp) {
+		t.Error(test.NewContext().DiffString("Result", got, exp, cmp.Diff(got, exp)))
+	}
+
+	count, err := CommentCount(post)
+	if err != nil {
+		t.Error(err)
+	}
+	test.AssertLabel(t, "CommentCount", count, 3)
+
+	got, err = Comments(&Post{Filename: "post2"})
+	if err != nil {
+		t.Error(err)
+	}
+	if got != nil {
+		t.Error(test.NewContext().GotExpString("No comments", got, nil))
+	}
+}
+
+func TestCommentBodyHTML(t *testing.T) {
+	testCases := []struct {
+		body string
+		exp  string
+	}{
+		{"[Alice](https://alice.test)", `<p><a href="https://alice.test">Alice</a></p>` + "\n"},
+		{"[Email](mailto:alice@alice.test)", `<p><a href="mailto:alice@alice.test">Email</a></p>` + "\n"},
+		{"[x](javascript:alert(1))", "<p><tt>x</tt>)</p>\n"},
+		{"[x](javascript:alert%281%29)", "<p><tt>x</tt></p>\n"},
+		{"[x](JavaScript:alert%281%29)", "<p><tt>x</tt></p>\n"},
+		{"[x](data:text/html;base64,PHNjcmlwdD4=)", "<p><tt>x</tt></p>\n"},
+		{`<a href="javascript:alert(1)">x</a>`, "<p>x</p>\n"},
+	}
+
+	for testCaseIndex, tc := range testCases {
+		context := test.NewContext().SetFields(test.ContextFields{
+			"index": testCaseIndex,
+			"body":  tc.body,
+		})
+
+		got := commentBodyHTML(tc.body)
+		if got != tc.exp {
+			t.Error(context.GotExpString("Result", got, tc.exp))
+		}
+	}
+}
+
+func TestComments_Invalid(t *testing.T) {
+	testCases := []struct {
+		files map[string]string
+		exp   string
+	}{
+		{map[string]string{"a.yml": "date: 2017-08-05T10:00:00Z\nbody: Hi"}, "missing author"},
+		{map[string]string{"a.yml": "author: Alice"}, "missing date, body"},
+		{map[string]string{"a.md": "---\nauthor: Alice\ndate: 2017-08-05T10:00:00Z\n---\n"}, "missing body"},
+		{map[string]string{"a.yml": "author: Alice\ndate: 2017-08-05T10:00:00Z\nbody: Hi\nreply_to: b"}, "comment a replies to b, which does not exist"},
+		{map[string]string{
+			"a.yml": "author: Alice\ndate: 2017-08-05T10:00:00Z\nbody: Hi\nreply_to: b",
+			"b.yml": "author: Bob\ndate: 2017-08-06T10:00:00Z\nbody: Hi\nreply_to: a",
+		}, "replies in a cycle, 0 of 2 comments are threaded"},
+	}
+
+	for testCaseIndex, tc := range testCases {
+		context := test.NewContext().SetFields(test.ContextFields{
+			"index": testCaseIndex,
+			"files": tc.files,
+		})
+
+		commentsPath, clean := test.SandboxDir(t, "comments")
+		dirPath := path.Join(commentsPath, "post")
+		err := os.MkdirAll(dirPath, 0755)
+		if err != nil {
+			t.Error(context.String(err))
+		}
+		for filename, content := range tc.files {
+			err = ioutil.WriteFile(path.Join(dirPath, filename), []byte(content), 0644)
+			if err != nil {
+				t.Error(context.String(err))
+			}
+		}
+
+		log, _ := logTest.NewNullLogger()
+		ResetCommentsMap()
+		settings := DefaultSettings()
+		settings.CommentsPath = commentsPath
+		Config(settings, log)
+
+		_, err = Comments(&Post{Filename: "post"})
+		if err == nil {
+			t.Error(context.String("no error"))
+		} else if !strings.HasSuffix(err.Error(), tc.exp) {
+			t.Error(context.GotExpString("Error", err.Error(), tc.exp))
+		}
+		clean()
+	}
+	configFactory()
+}
@@ -26,15 +26,23 @@ func NewFactory(settings *Settings, log logrus.FieldLogger) *Factory {
 
 func TestConfig(relative string, log logrus.FieldLogger) {
 	ResetPostMap()
+	ResetPageMap()
+	ResetCommentsMap()
 	settings := DefaultSettings()
 	settings.PostsPath = path.Join(relative, "posts")
 	settings.DraftsPath = path.Join(relative, "drafts")
+	settings.MarkdownsPath = path.Join(relative, "markdowns")
+	settings.CommentsPath = path.Join(relative, "comments")
 	Config(settings, log)
 }
 
 func TestSetPostDirEmpty(log logrus.FieldLogger) {
+	ResetPageMap()
+	ResetCommentsMap()
 	settings := DefaultSettings()
 	settings.PostsPath = "."
 	settings.DraftsPath = "."
+	settings.MarkdownsPath = "."
+	settings.CommentsPath = "."
 	Config(settings, log)
 }
@@ -0,0 +1,112 @@
+package models
+
+import (
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/russross/blackfriday"
+	"gopkg.in/yaml.v2"
+)
+
+const defaultPageLayout = "page"
+
+var pageMap = map[string]*Page{}
+
+func ResetPageMap() { pageMap = map[string]*Page{} }
+
+// Page is a markdown file in the MarkdownsPath with front matter, served at /<filename>
+type Page struct {
+	Title       string `yaml:"title"`
+	Description string `yaml:"description"`
+	// Layout is the template that renders the page, defaulting to "page"
+	Layout string `yaml:"layout"`
+	// NavWeight orders the page in the navigation, lowest first, pages without it are not in the navigation
+	NavWeight int `yaml:"nav_weight"`
+
+	Filename     string `yaml:"-"`
+	MarkdownHTML string `yaml:"-"`
+}
+
+func (page *Page) URL() string {
+	return "/" + page.Filename
+}
+
+// NewPage returns nil for markdown files without front matter
+func NewPage(filename string) (*Page, error) {
+	page, exists := pageMap[filename]
+	if exists {
+		return page, nil
+	}
+
+	input, err := ioutil.ReadFile(path.Join(factory.settings.MarkdownsPath, markdownFilename(filename)))
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(string(input), "---") {
+		page, err = pageParts(input)
+		if err != nil {
+			return nil, err
+		}
+		page.Filename = filename
+		if page.Layout == "" {
+			page.Layout = defaultPageLayout
+		}
+	}
+	pageMap[filename] = page
+	return page, nil
+}
+
+func pageParts(bytes []byte) (*Page, error) {
+	frontMatter, markdown, err := splitFrontMatter(string(bytes))
+	if err != nil {
+		return nil, err
+	}
+
+	page := Page{}
+	err = yaml.Unmarshal([]byte(frontMatter), &page)
+	if err != nil {
+		return nil, err
+	}
+	page.MarkdownHTML = string(blackfriday.Run([]byte(markdown)))
+	return &page, nil
+}
+
+// Pages are sorted by filename
+func Pages() ([]*Page, error) {
+	filenames, err := markdownFilenames(factory.settings.MarkdownsPath)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(filenames)
+
+	pages := []*Page{}
+	for _, filename := range filenames {
+		page, err := NewPage(filename)
+		if err != nil {
+			return nil, err
+		}
+		if page != nil {
+			pages = append(pages, page)
+		}
+	}
+	return pages, nil
+}
+
+// NavPages are the pages with a NavWeight, sorted by it
+func NavPages() ([]*Page, error) {
+	pages, err := Pages()
+	if err != nil {
+		return nil, err
+	}
+
+	navPages := []*Page{}
+	for _, page := range pages {
+		if page.NavWeight != 0 {
+			navPages = append(navPages, page)
+		}
+	}
+	sort.SliceStable(navPages, func(i, j int) bool { return navPages[i].NavWeight < navPages[j].NavWeight })
+	return navPages, nil
+}
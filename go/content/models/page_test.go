@@ -0,0 +1,103 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/s12chung/gostatic/go/test"
+)
+
+func TestPage_URL(t *testing.T) {
+	page := &Page{Filename: "now"}
+	test.AssertLabel(t, "Result", page.URL(), "/now")
+}
+
+func TestNewPage(t *testing.T) {
+	testCases := []struct {
+		filename string
+		exp      *Page
+	}{
+		{"now", &Page{"Now", "What I'm doing now", "page", 1, "now", "<p>Reading.</p>\n"}},
+		{"uses", &Page{"Uses", "", "uses", 0, "uses", "<p>A keyboard.</p>\n"}},
+		{"partial", nil},
+	}
+
+	for testCaseIndex, tc := range testCases {
+		context := test.NewContext().SetFields(test.ContextFields{
+			"index":    testCaseIndex,
+			"filename": tc.filename,
+		})
+
+		got, err := NewPage(tc.filename)
+		if err != nil {
+			t.Error(context.String(err))
+		}
+		if !cmp.Equal(got, tc.exp) {
+			t.Error(context.DiffString("Result", got, tc.exp, cmp.Diff(got, tc.exp)))
+		}
+
+		cached, err := NewPage(tc.filename)
+		if err != nil {
+			t.Error(context.String(err))
+		}
+		if cached != got {
+			t.Error(context.GotExpString("cached", cached, got))
+		}
+	}
+
+	_, err := NewPage("does not exist")
+	if err == nil {
+		t.Error("no error for not existing")
+	}
+}
+
+func TestPages(t *testing.T) {
+	testCases := []struct {
+		dirEmpty bool
+		exp      []string
+	}{
+		{true, []string{}},
+		{false, []string{"about", "now", "uses"}},
+	}
+
+	for testCaseIndex, tc := range testCases {
+		context := test.NewContext().SetFields(test.ContextFields{
+			"index":    testCaseIndex,
+			"dirEmpty": tc.dirEmpty,
+		})
+
+		configFactory()
+		if tc.dirEmpty {
+			setPostDirEmpty()
+		}
+
+		pages, err := Pages()
+		if err != nil {
+			t.Error(context.String(err))
+		}
+		got := make([]string, len(pages))
+		for i, page := range pages {
+			got[i] = page.Filename
+		}
+		if !cmp.Equal(got, tc.exp) {
+			t.Error(context.GotExpString("Result", got, tc.exp))
+		}
+	}
+	configFactory()
+}
+
+func TestNavPages(t *testing.T) {
+	pages, err := NavPages()
+	if err != nil {
+		t.Error(err)
+	}
+	got := make([]string, len(pages))
+	for i, page := range pages {
+		got[i] = page.Filename
+	}
+	exp := []string{"now", "about"}
+	if !cmp.Equal(got, exp) {
+		t.Error(test.NewContext().GotExpString("Result", got, exp))
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"path"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -22,13 +23,25 @@ var postMap = map[string]*Post{}
 
 func ResetPostMap() { postMap = map[string]*Post{} }
 
+// langRegex matches language codes used in filenames, like fr or pt-BR
+var langRegex = regexp.MustCompile(`^[a-z]{2}(-[A-Z]{2})?$`)
+
 type Post struct {
 	Title       string    `yaml:"title"`
 	Description string    `yaml:"description"`
+	Image       string    `yaml:"image"`
+	Books       []string  `yaml:"books"` // ISBNs or titles of the books the post discusses
 	PublishedAt time.Time `yaml:"published_at"`
+	UpdatedAt   time.Time `yaml:"updated_at"`
+
+	// Lang is empty for posts in the site's language, set by the front matter or the filename: my-post.fr.md
+	Lang string `yaml:"lang"`
+	// TranslationOf is the filename of the original post, defaulting to the filename without the language
+	TranslationOf string `yaml:"translation_of"`
 
 	Filename     string `yaml:"-"`
 	IsDraft      bool   `yaml:"-"`
+	Markdown     string `yaml:"-"` // without the front matter
 	MarkdownHTML string `yaml:"-"`
 }
 
@@ -36,6 +49,47 @@ func (post *Post) ID() string {
 	return post.Filename
 }
 
+func (post *Post) LastUpdatedAt() time.Time {
+	if post.UpdatedAt.IsZero() {
+		return post.PublishedAt
+	}
+	return post.UpdatedAt
+}
+
+// URL is under the language for translations: /fr/my-post
+func (post *Post) URL() string {
+	if post.Lang == "" {
+		return "/" + post.Filename
+	}
+	return "/" + post.Lang + "/" + strings.TrimSuffix(post.Filename, "."+post.Lang)
+}
+
+// translationKey is the same for a post and its translations
+func (post *Post) translationKey() string {
+	if post.TranslationOf != "" {
+		return post.TranslationOf
+	}
+	return post.Filename
+}
+
+// setLang sets the language from the filename of a translation of an existing post, my-post.fr.md of my-post.md,
+// so filenames like notes.go.md have no language. Posts in the site's language have no Lang
+func (post *Post) setLang() {
+	filenameLang := strings.TrimPrefix(path.Ext(post.Filename), ".")
+	original := strings.TrimSuffix(post.Filename, "."+filenameLang)
+	if langRegex.MatchString(filenameLang) && postExists(original) {
+		if post.Lang == "" {
+			post.Lang = filenameLang
+		}
+		if post.TranslationOf == "" {
+			post.TranslationOf = original
+		}
+	}
+	if post.Lang == factory.settings.Language {
+		post.Lang = ""
+	}
+}
+
 func (post *Post) MarkdownFilename() string {
 	return markdownFilename(post.Filename)
 }
@@ -84,8 +138,10 @@ func NewPost(filename string) (*Post, error) {
 		return nil, err
 	}
 	post.Filename = filename
+	post.Markdown = markdown
 	post.MarkdownHTML = string(blackfriday.Run([]byte(markdown)))
 	post.IsDraft = isDraft
+	post.setLang()
 	postMap[post.Filename] = post
 	return post, nil
 }
@@ -94,6 +150,37 @@ func Posts() ([]*Post, error) {
 	return AllPosts(func(post *Post) bool { return !post.IsDraft })
 }
 
+// Translations returns the published posts that are translations of each other with post, including post,
+// sorted by language with the site's language first
+func Translations(post *Post) ([]*Post, error) {
+	posts, err := AllPosts(func(other *Post) bool {
+		return other.translationKey() == post.translationKey() && (!other.IsDraft || other == post)
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(posts, func(i, j int) bool { return posts[i].Lang < posts[j].Lang })
+	return posts, nil
+}
+
+// Langs returns the sorted languages of the published posts that are not in the site's language
+func Langs() ([]string, error) {
+	posts, err := Posts()
+	if err != nil {
+		return nil, err
+	}
+	langMap := map[string]bool{}
+	langs := []string{}
+	for _, post := range posts {
+		if post.Lang != "" && !langMap[post.Lang] {
+			langMap[post.Lang] = true
+			langs = append(langs, post.Lang)
+		}
+	}
+	sort.Strings(langs)
+	return langs, nil
+}
+
 func AllPosts(sel func(*Post) bool) ([]*Post, error) {
 	err := fillPostMap()
 	if err != nil {
@@ -139,24 +226,24 @@ func toPosts(postMap map[string]*Post, sel func(*Post) bool) []*Post {
 func AllPostFilenames() ([]string, error) {
 	allPostURLs := []string{}
 
-	postsURLs, err := postFilenames(factory.settings.PostsPath)
+	postsURLs, err := markdownFilenames(factory.settings.PostsPath)
 	if err != nil {
 		return nil, err
 	}
 	allPostURLs = append(allPostURLs, postsURLs...)
 
-	draftURLs, err := postFilenames(factory.settings.DraftsPath)
+	draftURLs, err := markdownFilenames(factory.settings.DraftsPath)
 	if err != nil {
 		return nil, err
 	}
 	return append(allPostURLs, draftURLs...), nil
 }
 
-func postFilenames(postsDirPath string) ([]string, error) {
-	filePaths, err := utils.FilePaths(markdownExtension, postsDirPath)
+func markdownFilenames(dirPath string) ([]string, error) {
+	filePaths, err := utils.FilePaths(markdownExtension, dirPath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			factory.log.Warnf("Posts path does not exist %v - %v", postsDirPath, err)
+			factory.log.Warnf("Path does not exist %v - %v", dirPath, err)
 			return nil, nil
 		}
 		return nil, err
@@ -170,6 +257,11 @@ func postFilenames(postsDirPath string) ([]string, error) {
 	return filenames, nil
 }
 
+func postExists(filename string) bool {
+	_, _, err := postPath(filename)
+	return err == nil
+}
+
 func postPath(filename string) (string, bool, error) {
 	filename = markdownFilename(filename)
 	paths := []string{
@@ -222,6 +314,15 @@ func splitFrontMatter(content string) (string, string, error) {
 	return "", "", fmt.Errorf("FrontMatter format is not followed")
 }
 
+// WithoutFrontMatter returns the markdown of content, content does not need to have front matter
+func WithoutFrontMatter(content string) string {
+	_, markdown, err := splitFrontMatter(content)
+	if err != nil {
+		return content
+	}
+	return markdown
+}
+
 func markdownFilename(filename string) string {
 	return filename + ".md"
 }
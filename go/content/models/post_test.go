@@ -36,6 +36,140 @@ func TestPost_ID(t *testing.T) {
 	test.AssertLabel(t, "Result", post.ID(), post.Filename)
 }
 
+func TestPost_URL(t *testing.T) {
+	testCases := []struct {
+		filename string
+		lang     string
+		exp      string
+	}{
+		{"my-post", "", "/my-post"},
+		{"my-post.fr", "fr", "/fr/my-post"},
+		{"mon-article", "fr", "/fr/mon-article"},
+	}
+
+	for testCaseIndex, tc := range testCases {
+		context := test.NewContext().SetFields(test.ContextFields{
+			"index":    testCaseIndex,
+			"filename": tc.filename,
+		})
+		post := &Post{Filename: tc.filename, Lang: tc.lang}
+		if post.URL() != tc.exp {
+			t.Error(context.GotExpString("Result", post.URL(), tc.exp))
+		}
+	}
+}
+
+func TestNewPost_Lang(t *testing.T) {
+	configFactory()
+
+	post, err := NewPost("post1.fr")
+	if err != nil {
+		t.Error(err)
+	}
+	test.AssertLabel(t, "Lang", post.Lang, "fr")
+	test.AssertLabel(t, "TranslationOf", post.TranslationOf, "post1")
+
+	post, err = NewPost("post1")
+	if err != nil {
+		t.Error(err)
+	}
+	test.AssertLabel(t, "Lang", post.Lang, "")
+	test.AssertLabel(t, "TranslationOf", post.TranslationOf, "")
+}
+
+func TestPost_setLang(t *testing.T) {
+	testCases := []struct {
+		filename         string
+		lang             string
+		expLang          string
+		expTranslationOf string
+	}{
+		{"post1.fr", "", "fr", "post1"},
+		{"post1.fr", "pt-BR", "pt-BR", "post1"},
+		{"notes.go", "", "", ""},
+		{"my-post", "fr", "fr", ""},
+		{"my-post", "en", "", ""},
+		{"post1.en", "", "", "post1"},
+	}
+
+	for testCaseIndex, tc := range testCases {
+		context := test.NewContext().SetFields(test.ContextFields{
+			"index":    testCaseIndex,
+			"filename": tc.filename,
+			"lang":     tc.lang,
+		})
+
+		configFactory()
+		post := &Post{Filename: tc.filename, Lang: tc.lang}
+		post.setLang()
+		if post.Lang != tc.expLang {
+			t.Error(context.GotExpString("Lang", post.Lang, tc.expLang))
+		}
+		if post.TranslationOf != tc.expTranslationOf {
+			t.Error(context.GotExpString("TranslationOf", post.TranslationOf, tc.expTranslationOf))
+		}
+	}
+}
+
+func TestTranslations(t *testing.T) {
+	configFactory()
+
+	testCases := []struct {
+		filename string
+		exp      []string
+	}{
+		{"post1", []string{"post1", "post1.fr"}},
+		{"post1.fr", []string{"post1", "post1.fr"}},
+		{"post2", []string{"post2"}},
+	}
+
+	for testCaseIndex, tc := range testCases {
+		context := test.NewContext().SetFields(test.ContextFields{
+			"index":    testCaseIndex,
+			"filename": tc.filename,
+		})
+
+		post, err := NewPost(tc.filename)
+		if err != nil {
+			t.Error(context.String(err))
+		}
+		posts, err := Translations(post)
+		if err != nil {
+			t.Error(context.String(err))
+		}
+		got := make([]string, len(posts))
+		for i, translation := range posts {
+			got[i] = translation.Filename
+		}
+		if !cmp.Equal(got, tc.exp) {
+			t.Error(context.GotExpString("Result", got, tc.exp))
+		}
+	}
+}
+
+func TestLangs(t *testing.T) {
+	configFactory()
+
+	got, err := Langs()
+	if err != nil {
+		t.Error(err)
+	}
+	exp := []string{"fr"}
+	if !cmp.Equal(got, exp) {
+		t.Error(test.NewContext().GotExpString("Result", got, exp))
+	}
+}
+
+func TestPost_LastUpdatedAt(t *testing.T) {
+	publishedAt := time.Date(2017, 8, 1, 0, 0, 0, 0, time.UTC)
+	updatedAt := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	post := &Post{PublishedAt: publishedAt}
+	test.AssertLabel(t, "Result", post.LastUpdatedAt(), publishedAt)
+	post.UpdatedAt = updatedAt
+	test.AssertLabel(t, "Result", post.LastUpdatedAt(), updatedAt)
+}
+
 func TestPost_MarkdownFilename(t *testing.T) {
 	post := &Post{Filename: "some_filename"}
 	test.AssertLabel(t, "Result", post.MarkdownFilename(), post.Filename+".md")
@@ -129,12 +263,22 @@ func Test_NewPost(t *testing.T) {
 		if err != nil {
 			t.Error(context.String(err))
 		}
+		var books []string
+		if tc.filename == "post2" {
+			books = []string{"The Organization Man"}
+		}
 		exp := &Post{
 			title,
 			fmt.Sprintf("%v Dec", title),
+			"",
+			books,
 			time.Date(2017, time.Month(month), int(day), 0, 0, 0, 0, time.UTC),
+			time.Time{},
+			"",
+			"",
 			tc.filename,
 			isDraft,
+			fmt.Sprintf("The %v.", title),
 			fmt.Sprintf("<p>The %v.</p>\n", title),
 		}
 		if !cmp.Equal(post, exp) {
@@ -153,10 +297,10 @@ func TestAllPosts(t *testing.T) {
 	}{
 		{true, "all", 0},
 		{true, "draft", 0},
-		{false, "all", 5},
+		{false, "all", 6},
 		{false, "empty", 0},
 		{false, "draft", 3},
-		{false, "noDraft", 2},
+		{false, "noDraft", 3},
 	}
 
 	for testCaseIndex, tc := range testCases {
@@ -244,7 +388,7 @@ func TestAllPostFilenames(t *testing.T) {
 		exp          []string
 	}{
 		{true, []string{}},
-		{false, []string{"post1", "post2", "draft1", "draft2", "draft3"}},
+		{false, []string{"post1", "post1.fr", "post2", "draft1", "draft2", "draft3"}},
 	}
 
 	for _, tc := range testCases {
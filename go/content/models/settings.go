@@ -3,13 +3,22 @@ package models
 type Settings struct {
 	PostsPath  string `json:"posts_path,omitempty"`
 	DraftsPath string `json:"drafts_path,omitempty"`
-	GithubURL  string `json:"github_url,omitempty"`
+	// MarkdownsPath has the pages, markdown files with front matter, other markdown files are partials
+	MarkdownsPath string `json:"markdowns_path,omitempty"`
+	// CommentsPath has a directory of comments for each post, named by the post filename
+	CommentsPath string `json:"comments_path,omitempty"`
+	GithubURL    string `json:"github_url,omitempty"`
+	// Language is the site's language, from the site settings, posts in it have no Lang
+	Language string `json:"-"`
 }
 
 func DefaultSettings() *Settings {
 	return &Settings{
 		"./content/posts",
 		"./content/drafts",
+		"./content/markdowns",
+		"./content/comments",
 		"",
+		"en",
 	}
 }
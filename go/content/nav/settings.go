@@ -0,0 +1,22 @@
+package nav
+
+// Item is a link in the navigation, pages with a nav_weight are added with their weight as the Order
+type Item struct {
+	Label    string `json:"label"`
+	URL      string `json:"url"`
+	External bool   `json:"external,omitempty"`
+	// Order sorts the items, lowest first
+	Order int `json:"order,omitempty"`
+}
+
+type Settings struct {
+	Items []*Item `json:"items,omitempty"`
+}
+
+func DefaultSettings() *Settings {
+	return &Settings{
+		[]*Item{
+			{"Reading", "/reading", false, 1},
+		},
+	}
+}
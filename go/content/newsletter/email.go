@@ -0,0 +1,224 @@
+package newsletter
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"fmt"
+	"html/template"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/s12chung/go_homepage/go/content/atom"
+	"github.com/s12chung/go_homepage/go/content/models"
+	"github.com/s12chung/go_homepage/go/content/site"
+)
+
+const dateFormat = "January 2, 2006"
+
+// htmlTemplate lays out the post like the site, the content gets its styles from inlineStyles
+var htmlTemplate = template.Must(template.New("email").Parse(`<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+</head>
+<body style="` + tagStyles["body"] + `">
+<table role="presentation" width="100%" cellpadding="0" cellspacing="0" border="0" style="border-collapse: collapse;">
+<tr>
+<td align="center" style="padding: 24px 16px;">
+<table role="presentation" width="100%" cellpadding="0" cellspacing="0" border="0" style="max-width: 600px; border-collapse: collapse;">
+<tr>
+<td align="left">
+<h1 style="` + tagStyles["h1"] + `">{{.Title}}</h1>
+<p style="` + tagStyles["p"] + ` color: ` + inkLightColor + `; font-size: 14px;">{{.Date}}</p>
+{{.Content}}
+<hr style="` + tagStyles["hr"] + `">
+<p style="` + tagStyles["p"] + ` font-size: 14px;"><a href="{{.URL}}" style="` + tagStyles["a"] + `">Read on {{.SiteName}}</a></p>
+</td>
+</tr>
+</table>
+</td>
+</tr>
+</table>
+</body>
+</html>
+`))
+
+// Email is a post rendered for email clients
+type Email struct {
+	From    string
+	To      string
+	Subject string
+	// Date is when the post was published
+	Date time.Time
+	HTML string
+	Text string
+}
+
+// ManifestImageURL maps the post images to their webpack manifest URL, like replaceResponsiveAttrs "content"
+func ManifestImageURL(manifestURL func(key string) string) atom.ImageURL {
+	return func(src string) string {
+		return manifestURL(path.Join("content", src))
+	}
+}
+
+// NewEmail renders the post with inlined styles and absolute URLs, along with a plain text alternative from its markdown.
+// The relative post images are mapped with imageURL, as they are not served relative to the post
+func NewEmail(post *models.Post, siteSettings *site.Settings, settings *Settings, imageURL atom.ImageURL) (*Email, error) {
+	if siteSettings.URL == "" {
+		return nil, fmt.Errorf("the site url setting is needed for the absolute URLs of the newsletter")
+	}
+	postURL := siteSettings.AbsoluteURL(post.URL())
+	base, err := url.Parse(postURL)
+	if err != nil {
+		return nil, err
+	}
+
+	lang := post.Lang
+	if lang == "" {
+		lang = siteSettings.Language
+	}
+	var buffer bytes.Buffer
+	err = htmlTemplate.Execute(&buffer, map[string]interface{}{
+		"Lang":     lang,
+		"Title":    post.Title,
+		"Date":     post.PublishedAt.Format(dateFormat),
+		"Content":  template.HTML(inlineStyles(atom.AbsoluteHTML(post.MarkdownHTML, postURL, imageURL))),
+		"URL":      postURL,
+		"SiteName": siteSettings.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	text := strings.Join([]string{
+		post.Title,
+		post.PublishedAt.Format(dateFormat),
+		markdownText(post.Markdown, base, imageURL),
+		"---",
+		"Read on " + siteSettings.Name + ": " + postURL,
+	}, "\n\n") + "\n"
+
+	return &Email{
+		settings.From,
+		settings.To,
+		post.Title,
+		post.PublishedAt,
+		buffer.String(),
+		text,
+	}, nil
+}
+
+// EML is a multipart/alternative message with the Text and HTML, which mail clients open as a draft or import
+func (email *Email) EML() ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := multipart.NewWriter(&buffer)
+	// the boundary is from the content, so rendering again gives the same file
+	err := writer.SetBoundary(fmt.Sprintf("%x", sha1.Sum([]byte(email.Text+email.HTML))))
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := email.header(writer.Boundary())
+	if err != nil {
+		return nil, err
+	}
+	for _, part := range []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=utf-8", email.Text},
+		{"text/html; charset=utf-8", email.HTML},
+	} {
+		err = writePart(writer, part.contentType, part.content)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = writer.Close()
+	if err != nil {
+		return nil, err
+	}
+	return append(header, buffer.Bytes()...), nil
+}
+
+func (email *Email) header(boundary string) ([]byte, error) {
+	var lines []string
+	for _, address := range []struct {
+		key   string
+		value string
+	}{
+		{"From", email.From},
+		{"To", email.To},
+	} {
+		if address.value == "" {
+			continue
+		}
+		list, err := mail.ParseAddressList(address.value)
+		if err != nil {
+			return nil, fmt.Errorf("%v address %v: %v", address.key, address.value, err)
+		}
+		formatted := make([]string, len(list))
+		for i, parsed := range list {
+			formatted[i] = parsed.String()
+		}
+		lines = append(lines, address.key+": "+strings.Join(formatted, ", "))
+	}
+	lines = append(lines,
+		"Subject: "+mime.QEncoding.Encode("utf-8", email.Subject),
+		"Date: "+email.Date.Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		"Content-Type: multipart/alternative; boundary="+boundary,
+	)
+	return []byte(strings.Join(lines, "\r\n") + "\r\n\r\n"), nil
+}
+
+func writePart(writer *multipart.Writer, contentType, content string) error {
+	partWriter, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {contentType},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return err
+	}
+	encoder := quotedprintable.NewWriter(partWriter)
+	_, err = encoder.Write([]byte(content))
+	if err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// Write writes the .eml, .html and .txt files of the email to the dirPath, named by the filename
+func (email *Email) Write(dirPath, filename string) error {
+	eml, err := email.EML()
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(dirPath, 0755)
+	if err != nil {
+		return err
+	}
+	for ext, content := range map[string][]byte{
+		".eml":  eml,
+		".html": []byte(email.HTML),
+		".txt":  []byte(email.Text),
+	} {
+		err = ioutil.WriteFile(path.Join(dirPath, filename+ext), content, 0644)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
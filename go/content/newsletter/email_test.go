@@ -0,0 +1,203 @@
+package newsletter
+
+import (
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/s12chung/gostatic/go/test"
+
+	"github.com/s12chung/go_homepage/go/content/models"
+	"github.com/s12chung/go_homepage/go/content/site"
+)
+
+func testSiteSettings() *site.Settings {
+	settings := site.DefaultSettings()
+	settings.Name = "Test"
+	settings.URL = "https://test.com"
+	return settings
+}
+
+func testSettings() *Settings {
+	settings := DefaultSettings()
+	settings.From = "Test <hello@test.com>"
+	settings.To = "list@test.com"
+	return settings
+}
+
+var testImageURL = ManifestImageURL(func(key string) string { return "/assets/" + key })
+
+func testPost() *models.Post {
+	return &models.Post{
+		Title:        "Café & Co",
+		PublishedAt:  time.Date(2018, 1, 2, 0, 0, 0, 0, time.UTC),
+		Filename:     "some_post",
+		Markdown:     "A ![cat](images/cat.png) and [about](/about).",
+		MarkdownHTML: `<p>A <img src="images/cat.png" alt="cat"> and <a href="/about">about</a>.</p>` + "\n",
+	}
+}
+
+func testEmail(t *testing.T) *Email {
+	email, err := NewEmail(testPost(), testSiteSettings(), testSettings(), testImageURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return email
+}
+
+func TestNewEmail(t *testing.T) {
+	email := testEmail(t)
+
+	context := test.NewContext()
+	test.AssertLabel(t, "From", email.From, "Test <hello@test.com>")
+	test.AssertLabel(t, "To", email.To, "list@test.com")
+	test.AssertLabel(t, "Subject", email.Subject, "Café & Co")
+	test.AssertLabel(t, "Date", email.Date, time.Date(2018, 1, 2, 0, 0, 0, 0, time.UTC))
+
+	for _, exp := range []string{
+		`<html lang="en">`,
+		`<title>Café &amp; Co</title>`,
+		`<h1 style="` + tagStyles["h1"] + `">Café &amp; Co</h1>`,
+		`<p style="` + tagStyles["p"] + `">A <img src="https://test.com/assets/content/images/cat.png" alt="cat" style="` + tagStyles["img"] + `">`,
+		`<a href="https://test.com/about" style="` + tagStyles["a"] + `">about</a>`,
+		`<a href="https://test.com/some_post" style="` + tagStyles["a"] + `">Read on Test</a>`,
+	} {
+		if !strings.Contains(email.HTML, exp) {
+			t.Error(context.GotExpString("HTML", email.HTML, exp))
+		}
+	}
+
+	expText := strings.Join([]string{
+		"Café & Co",
+		"January 2, 2018",
+		"A cat (https://test.com/assets/content/images/cat.png) and about (https://test.com/about).",
+		"---",
+		"Read on Test: https://test.com/some_post",
+	}, "\n\n") + "\n"
+	test.AssertLabel(t, "Text", email.Text, expText)
+
+	post := testPost()
+	post.Lang = "fr"
+	email, err := NewEmail(post, testSiteSettings(), testSettings(), testImageURL)
+	if err != nil {
+		t.Error(err)
+	}
+	for _, exp := range []string{`<html lang="fr">`, `<img src="https://test.com/assets/content/images/cat.png"`} {
+		if !strings.Contains(email.HTML, exp) {
+			t.Error(context.GotExpString("HTML", email.HTML, exp))
+		}
+	}
+
+	siteSettings := testSiteSettings()
+	siteSettings.URL = ""
+	_, err = NewEmail(testPost(), siteSettings, testSettings(), testImageURL)
+	if err == nil {
+		t.Error("no error without the site url")
+	}
+}
+
+func TestEmail_EML(t *testing.T) {
+	email := testEmail(t)
+	eml, err := email.EML()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	again, err := email.EML()
+	if err != nil {
+		t.Error(err)
+	}
+	if string(again) != string(eml) {
+		t.Error("rendering again gives a different file")
+	}
+
+	message, err := mail.ReadMessage(strings.NewReader(string(eml)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(message.Header.Get("Subject"))
+	if err != nil {
+		t.Error(err)
+	}
+	test.AssertLabel(t, "From", message.Header.Get("From"), `"Test" <hello@test.com>`)
+	test.AssertLabel(t, "To", message.Header.Get("To"), "<list@test.com>")
+	test.AssertLabel(t, "Subject", subject, email.Subject)
+	test.AssertLabel(t, "Date", message.Header.Get("Date"), "Tue, 02 Jan 2018 00:00:00 +0000")
+
+	mediaType, params, err := mime.ParseMediaType(message.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertLabel(t, "mediaType", mediaType, "multipart/alternative")
+
+	reader := multipart.NewReader(message.Body, params["boundary"])
+	for _, exp := range []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=utf-8", email.Text},
+		{"text/html; charset=utf-8", email.HTML},
+	} {
+		context := test.NewContext().SetFields(test.ContextFields{
+			"contentType": exp.contentType,
+		})
+
+		part, err := reader.NextPart()
+		if err != nil {
+			t.Fatal(context.String(err))
+		}
+		if part.Header.Get("Content-Type") != exp.contentType {
+			t.Error(context.GotExpString("Content-Type", part.Header.Get("Content-Type"), exp.contentType))
+		}
+		content, err := ioutil.ReadAll(part)
+		if err != nil {
+			t.Error(context.String(err))
+		}
+		// quoted-printable text has CRLF line endings
+		got := strings.Replace(string(content), "\r\n", "\n", -1)
+		if got != exp.content {
+			t.Error(context.GotExpString("content", got, exp.content))
+		}
+	}
+
+	email.From = "not an address"
+	_, err = email.EML()
+	if err == nil {
+		t.Error("no error for an invalid From address")
+	}
+}
+
+func TestEmail_Write(t *testing.T) {
+	email := testEmail(t)
+	outputPath, clean := test.SandboxDir(t, DefaultSettings().OutputPath)
+	defer clean()
+
+	err := email.Write(outputPath, "some_post")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	eml, err := email.EML()
+	if err != nil {
+		t.Error(err)
+	}
+	for filename, exp := range map[string]string{
+		"some_post.eml":  string(eml),
+		"some_post.html": email.HTML,
+		"some_post.txt":  email.Text,
+	} {
+		got, err := ioutil.ReadFile(path.Join(outputPath, filename))
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if string(got) != exp {
+			t.Error(test.NewContext().SetFields(test.ContextFields{"filename": filename}).GotExpString("content", string(got), exp))
+		}
+	}
+}
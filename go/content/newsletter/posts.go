@@ -0,0 +1,25 @@
+package newsletter
+
+import (
+	"sort"
+	"time"
+
+	"github.com/s12chung/go_homepage/go/content/models"
+)
+
+// PostsSince are the published posts in the site's language published on or after since, oldest first
+func PostsSince(since time.Time) ([]*models.Post, error) {
+	posts, err := models.Posts()
+	if err != nil {
+		return nil, err
+	}
+
+	var selected []*models.Post
+	for _, post := range posts {
+		if post.Lang == "" && !post.PublishedAt.Before(since) {
+			selected = append(selected, post)
+		}
+	}
+	sort.SliceStable(selected, func(i, j int) bool { return selected[i].PublishedAt.Before(selected[j].PublishedAt) })
+	return selected, nil
+}
@@ -0,0 +1,47 @@
+package newsletter
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+
+	"github.com/s12chung/gostatic/go/test"
+
+	"github.com/s12chung/go_homepage/go/content/models"
+)
+
+func TestPostsSince(t *testing.T) {
+	log, _ := logTest.NewNullLogger()
+	models.TestConfig(path.Join("../models", test.FixturePath), log)
+
+	testCases := []struct {
+		since time.Time
+		exp   []string
+	}{
+		{time.Date(2017, 8, 1, 0, 0, 0, 0, time.UTC), []string{"post1", "post2"}},
+		{time.Date(2017, 8, 2, 0, 0, 0, 0, time.UTC), []string{"post2"}},
+		{time.Date(2017, 8, 3, 0, 0, 0, 0, time.UTC), []string{}},
+	}
+
+	for testCaseIndex, tc := range testCases {
+		context := test.NewContext().SetFields(test.ContextFields{
+			"index": testCaseIndex,
+			"since": tc.since,
+		})
+
+		posts, err := PostsSince(tc.since)
+		if err != nil {
+			t.Error(context.String(err))
+		}
+		got := make([]string, len(posts))
+		for i, post := range posts {
+			got[i] = post.Filename
+		}
+		if !cmp.Equal(got, tc.exp) {
+			t.Error(context.GotExpString("Result", got, tc.exp))
+		}
+	}
+}
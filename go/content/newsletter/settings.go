@@ -0,0 +1,17 @@
+package newsletter
+
+type Settings struct {
+	// From and To are the addresses of the email, like "Your Name <you@yourwebsite.com>", To is usually the mailing list
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+	// OutputPath has the .eml, .html and .txt files of each post rendered
+	OutputPath string `json:"output_path,omitempty"`
+}
+
+func DefaultSettings() *Settings {
+	return &Settings{
+		"",
+		"",
+		"./newsletter",
+	}
+}
@@ -0,0 +1,83 @@
+package newsletter
+
+import (
+	"bytes"
+	"io"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+const (
+	inkColor        = "#3a3537"
+	inkLightColor   = "#666163"
+	lightGreyColor  = "#bdbbbc"
+	backgroundColor = "#e9e8e9"
+	blueColor       = "#5093a3"
+
+	serifFonts     = "Constantia, 'Lucida Bright', Georgia, serif"
+	sansSerifFonts = "'Helvetica Neue', Helvetica, Arial, sans-serif"
+	monospaceFonts = "'Courier New', Courier, monospace"
+)
+
+// tagStyles follow the site's style sheets, email clients ignore style sheets, so they are set on each tag
+var tagStyles = map[string]string{
+	"body":       "margin: 0; padding: 0; background-color: #ffffff; color: " + inkColor + "; font-family: " + serifFonts + "; font-size: 18px; line-height: 1.5;",
+	"h1":         "margin: 0 0 8px; font-family: " + sansSerifFonts + "; font-size: 32px; line-height: 1.2; font-weight: 600;",
+	"h2":         "margin: 32px 0 16px; font-family: " + sansSerifFonts + "; font-size: 24px; line-height: 1.25; font-weight: 600;",
+	"h3":         "margin: 24px 0 16px; font-family: " + sansSerifFonts + "; font-size: 20px; line-height: 1.25; font-weight: 600;",
+	"h4":         "margin: 24px 0 16px; font-family: " + sansSerifFonts + "; font-size: 18px; line-height: 1.25; font-weight: 600;",
+	"p":          "margin: 0 0 16px;",
+	"a":          "color: " + blueColor + "; text-decoration: underline;",
+	"img":        "display: block; max-width: 100%; height: auto; border: 0;",
+	"blockquote": "margin: 0 0 16px; padding: 0 0 0 16px; border-left: 4px solid " + lightGreyColor + "; color: " + inkLightColor + ";",
+	"ul":         "margin: 0 0 16px; padding: 0 0 0 24px;",
+	"ol":         "margin: 0 0 16px; padding: 0 0 0 24px;",
+	"li":         "margin: 0 0 4px;",
+	"pre":        "margin: 0 0 16px; padding: 12px; background-color: " + backgroundColor + "; white-space: pre-wrap; word-wrap: break-word;",
+	"code":       "font-family: " + monospaceFonts + "; font-size: 16px;",
+	"hr":         "margin: 32px 0; border: 0; border-top: 1px solid " + lightGreyColor + ";",
+	"table":      "margin: 0 0 16px; border-collapse: collapse;",
+	"th":         "padding: 4px 8px; border-bottom: 1px solid " + lightGreyColor + "; text-align: left;",
+	"td":         "padding: 4px 8px; border-bottom: 1px solid " + backgroundColor + ";",
+}
+
+// inlineStyles sets the tagStyles in the style attributes of the tags of htmlString, before the styles already there
+func inlineStyles(htmlString string) string {
+	var buffer bytes.Buffer
+	tokenizer := html.NewTokenizer(strings.NewReader(htmlString))
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			if tokenizer.Err() != io.EOF {
+				return htmlString
+			}
+			return buffer.String()
+		}
+
+		raw := string(tokenizer.Raw())
+		if tokenType != html.StartTagToken && tokenType != html.SelfClosingTagToken {
+			buffer.WriteString(raw)
+			continue
+		}
+
+		token := tokenizer.Token()
+		style, exists := tagStyles[token.Data]
+		if !exists {
+			buffer.WriteString(raw)
+			continue
+		}
+
+		styled := false
+		for i, attr := range token.Attr {
+			if attr.Key == "style" {
+				token.Attr[i].Val = style + " " + attr.Val
+				styled = true
+			}
+		}
+		if !styled {
+			token.Attr = append(token.Attr, html.Attribute{Key: "style", Val: style})
+		}
+		buffer.WriteString(token.String())
+	}
+}
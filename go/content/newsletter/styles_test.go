@@ -0,0 +1,32 @@
+package newsletter
+
+import (
+	"testing"
+
+	"github.com/s12chung/gostatic/go/test"
+)
+
+func TestInlineStyles(t *testing.T) {
+	testCases := []struct {
+		html string
+		exp  string
+	}{
+		{"", ""},
+		{"<span>Not styled &amp; kept.</span>", "<span>Not styled &amp; kept.</span>"},
+		{"<p>Text</p>", `<p style="` + tagStyles["p"] + `">Text</p>`},
+		{`<a href="/about" style="color: red;">About</a>`, `<a href="/about" style="` + tagStyles["a"] + ` color: red;">About</a>`},
+		{`<img src="a.png"/>`, `<img src="a.png" style="` + tagStyles["img"] + `"/>`},
+	}
+
+	for testCaseIndex, tc := range testCases {
+		context := test.NewContext().SetFields(test.ContextFields{
+			"index": testCaseIndex,
+			"html":  tc.html,
+		})
+
+		got := inlineStyles(tc.html)
+		if got != tc.exp {
+			t.Error(context.GotExpString("Result", got, tc.exp))
+		}
+	}
+}
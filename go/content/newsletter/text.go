@@ -0,0 +1,177 @@
+package newsletter
+
+import (
+	"bytes"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/russross/blackfriday"
+	"golang.org/x/net/html"
+
+	"github.com/s12chung/go_homepage/go/content/atom"
+)
+
+// markdownText renders the markdown as plain text, links and images are followed by their URL resolved against base,
+// the relative images are mapped by imageURL first, if it is not nil
+func markdownText(markdown string, base *url.URL, imageURL atom.ImageURL) string {
+	root := blackfriday.New(blackfriday.WithExtensions(blackfriday.CommonExtensions)).Parse([]byte(markdown))
+	return strings.Trim(textRenderer{base, imageURL}.blocks(root, "\n\n"), "\n")
+}
+
+type textRenderer struct {
+	base     *url.URL
+	imageURL atom.ImageURL
+}
+
+func (renderer textRenderer) blocks(parent *blackfriday.Node, separator string) string {
+	var blocks []string
+	for node := parent.FirstChild; node != nil; node = node.Next {
+		block := renderer.block(node)
+		if block != "" {
+			blocks = append(blocks, block)
+		}
+	}
+	return strings.Join(blocks, separator)
+}
+
+func (renderer textRenderer) block(node *blackfriday.Node) string {
+	switch node.Type {
+	case blackfriday.Heading:
+		text := renderer.inline(node)
+		underline := "-"
+		if node.Level == 1 {
+			underline = "="
+		}
+		return text + "\n" + strings.Repeat(underline, utf8.RuneCountInString(text))
+	case blackfriday.BlockQuote:
+		return prefixLines(renderer.blocks(node, "\n\n"), "> ", "> ")
+	case blackfriday.List:
+		return renderer.list(node)
+	case blackfriday.CodeBlock:
+		return prefixLines(strings.TrimRight(string(node.Literal), "\n"), "    ", "    ")
+	case blackfriday.HorizontalRule:
+		return "---"
+	case blackfriday.HTMLBlock:
+		return htmlText(string(node.Literal))
+	case blackfriday.Table:
+		return renderer.table(node)
+	}
+	return renderer.inline(node)
+}
+
+func (renderer textRenderer) list(list *blackfriday.Node) string {
+	separator := "\n"
+	if !list.Tight {
+		separator = "\n\n"
+	}
+
+	var items []string
+	number := 1
+	for item := list.FirstChild; item != nil; item = item.Next {
+		bullet := "- "
+		if list.ListFlags&blackfriday.ListTypeOrdered != 0 {
+			bullet = strconv.Itoa(number) + ". "
+			number++
+		}
+		items = append(items, prefixLines(renderer.blocks(item, separator), bullet, strings.Repeat(" ", len(bullet))))
+	}
+	return strings.Join(items, separator)
+}
+
+func (renderer textRenderer) table(table *blackfriday.Node) string {
+	var rows []string
+	table.Walk(func(node *blackfriday.Node, entering bool) blackfriday.WalkStatus {
+		if node.Type != blackfriday.TableRow || !entering {
+			return blackfriday.GoToNext
+		}
+		var cells []string
+		for cell := node.FirstChild; cell != nil; cell = cell.Next {
+			cells = append(cells, renderer.inline(cell))
+		}
+		rows = append(rows, strings.Join(cells, " | "))
+		return blackfriday.SkipChildren
+	})
+	return strings.Join(rows, "\n")
+}
+
+func (renderer textRenderer) inline(parent *blackfriday.Node) string {
+	var buffer bytes.Buffer
+	for node := parent.FirstChild; node != nil; node = node.Next {
+		switch node.Type {
+		case blackfriday.Text, blackfriday.Code:
+			buffer.Write(node.Literal)
+		case blackfriday.Softbreak, blackfriday.Hardbreak:
+			buffer.WriteString("\n")
+		case blackfriday.Link, blackfriday.Image:
+			buffer.WriteString(renderer.link(node))
+		case blackfriday.HTMLSpan:
+			// the tag is dropped, the text inside it is a sibling
+		default:
+			buffer.WriteString(renderer.inline(node))
+		}
+	}
+	return buffer.String()
+}
+
+func (renderer textRenderer) link(node *blackfriday.Node) string {
+	text := renderer.inline(node)
+	destination := string(node.Destination)
+	if node.Type == blackfriday.Image && renderer.imageURL != nil && isRelative(destination) {
+		destination = renderer.imageURL(strings.TrimSpace(destination))
+	}
+	destination = resolve(renderer.base, destination)
+	if text == "" || text == destination {
+		return destination
+	}
+	return text + " (" + destination + ")"
+}
+
+func isRelative(href string) bool {
+	u, err := url.Parse(strings.TrimSpace(href))
+	return err == nil && !u.IsAbs() && u.Host == ""
+}
+
+func resolve(base *url.URL, href string) string {
+	u, err := url.Parse(strings.TrimSpace(href))
+	if err != nil || u.IsAbs() {
+		return href
+	}
+	return base.ResolveReference(u).String()
+}
+
+// prefixLines prefixes the first line with first and the other lines with rest
+func prefixLines(text, first, rest string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		prefix := rest
+		if i == 0 {
+			prefix = first
+		}
+		if line == "" {
+			prefix = strings.TrimRight(prefix, " ")
+		}
+		lines[i] = prefix + line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// htmlText is the text of the HTML, with the whitespace collapsed
+func htmlText(htmlString string) string {
+	var texts []string
+	tokenizer := html.NewTokenizer(strings.NewReader(htmlString))
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			if tokenizer.Err() != io.EOF {
+				return ""
+			}
+			return strings.Join(strings.Fields(strings.Join(texts, " ")), " ")
+		}
+		if tokenType == html.TextToken {
+			texts = append(texts, tokenizer.Token().Data)
+		}
+	}
+}
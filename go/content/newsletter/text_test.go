@@ -0,0 +1,48 @@
+package newsletter
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/s12chung/gostatic/go/test"
+)
+
+func TestMarkdownText(t *testing.T) {
+	base, err := url.Parse("https://test.com/some_post")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		markdown string
+		exp      string
+	}{
+		{"", ""},
+		{"Some *emphasis*, **strong** and `code`.", "Some emphasis, strong and code."},
+		{"# Title\n\n## Sub Title\n\nText.", "Title\n=====\n\nSub Title\n---------\n\nText."},
+		{"A [link](/about) and [https://other.com](https://other.com).", "A link (https://test.com/about) and https://other.com."},
+		{"![A cat](images/cat.png)", "A cat (https://test.com/assets/content/images/cat.png)"},
+		{"![](/images/cat.png)", "https://test.com/assets/content/images/cat.png"},
+		{"![A cat](https://other.com/cat.png)", "A cat (https://other.com/cat.png)"},
+		{"[A cat](images/cat.png)", "A cat (https://test.com/images/cat.png)"},
+		{"- one\n- two\n    1. first\n    2. second", "- one\n- two\n  1. first\n  2. second"},
+		{"> Quoted\n>\n> Again", "> Quoted\n>\n> Again"},
+		{"```\nfunc main() {\n}\n```", "    func main() {\n    }"},
+		{"Above\n\n---\n\nBelow", "Above\n\n---\n\nBelow"},
+		{"<div class=\"note\">\n  <p>An HTML   note.</p>\n</div>\n\nAfter.", "An HTML note.\n\nAfter."},
+		{"Some <em>inline</em> HTML.", "Some inline HTML."},
+		{"| A | B |\n|---|---|\n| 1 | 2 |", "A | B\n1 | 2"},
+	}
+
+	for testCaseIndex, tc := range testCases {
+		context := test.NewContext().SetFields(test.ContextFields{
+			"index":    testCaseIndex,
+			"markdown": tc.markdown,
+		})
+
+		got := markdownText(tc.markdown, base, testImageURL)
+		if got != tc.exp {
+			t.Error(context.GotExpString("Result", got, tc.exp))
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package reading
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/russross/blackfriday"
+)
+
+// URL is the URL of the reading page, book pages are nested under it
+const URL = "/reading"
+
+const (
+	ReadShelf             = "read"
+	CurrentlyReadingShelf = "currently-reading"
+	ToReadShelf           = "to-read"
+)
+
+type Book struct {
+	ID      string   `yaml:"id"`
+	Title   string   `yaml:"title"`
+	Authors []string `yaml:"authors"`
+	ISBN    string   `yaml:"isbn"`
+	ISBN13  string   `yaml:"isbn13"`
+	Rating  int      `yaml:"rating"`
+	Pages   int      `yaml:"pages"`
+
+	// Shelf is the exclusive shelf: read, currently-reading or to-read
+	Shelf   string   `yaml:"shelf"`
+	Shelves []string `yaml:"shelves"`
+
+	AddedAt time.Time `yaml:"added_at"`
+	ReadAt  time.Time `yaml:"read_at"`
+
+	Review string `yaml:"review"`
+}
+
+func (book *Book) SortedDate() time.Time {
+	if book.ReadAt.IsZero() {
+		return book.AddedAt
+	}
+	return book.ReadAt
+}
+
+// Slug is the title in lowercase with dashes between words
+func (book *Book) Slug() string {
+	return Slug(book.Title)
+}
+
+// URL is the URL of the book's page, only books with reviews have pages
+func (book *Book) URL() string {
+	return URL + "/" + book.Slug()
+}
+
+func (book *Book) HasReview() bool {
+	return strings.TrimSpace(book.Review) != ""
+}
+
+// ReviewHTML renders the review's markdown, HTML in reviews (like Goodreads reviews) is kept
+func (book *Book) ReviewHTML() string {
+	return string(blackfriday.Run([]byte(book.Review)))
+}
+
+// Slug returns s in lowercase with dashes between words, used in URLs
+func Slug(s string) string {
+	var words []string
+	word := ""
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			word += string(r)
+			continue
+		}
+		if word != "" {
+			words = append(words, word)
+			word = ""
+		}
+	}
+	if word != "" {
+		words = append(words, word)
+	}
+	return strings.Join(words, "-")
+}
+
+func ReadBooks(books []*Book) []*Book {
+	var readBooks []*Book
+	for _, book := range books {
+		if book.Shelf == ReadShelf {
+			readBooks = append(readBooks, book)
+		}
+	}
+	return readBooks
+}
+
+// BooksOnShelf returns the books with shelf as its exclusive shelf or as one of its shelves
+func BooksOnShelf(books []*Book, shelf string) []*Book {
+	var shelfBooks []*Book
+	for _, book := range books {
+		if book.Shelf == shelf {
+			shelfBooks = append(shelfBooks, book)
+			continue
+		}
+		for _, bookShelf := range book.Shelves {
+			if bookShelf == shelf {
+				shelfBooks = append(shelfBooks, book)
+				break
+			}
+		}
+	}
+	return shelfBooks
+}
+
+// Shelves returns the sorted, unique non-exclusive shelves of the books
+func Shelves(books []*Book) []string {
+	shelfMap := map[string]bool{}
+	shelves := []string{}
+	for _, book := range books {
+		for _, shelf := range book.Shelves {
+			if !shelfMap[shelf] {
+				shelfMap[shelf] = true
+				shelves = append(shelves, shelf)
+			}
+		}
+	}
+	sort.Strings(shelves)
+	return shelves
+}
+
+// FindBook returns the book with ref as its ISBN or title, titles also match without subtitles
+func FindBook(books []*Book, ref string) *Book {
+	isbn := strings.Replace(strings.Replace(ref, "-", "", -1), " ", "", -1)
+	for _, book := range books {
+		if isbn != "" && (isbn == book.ISBN || isbn == book.ISBN13) {
+			return book
+		}
+	}
+	for _, book := range books {
+		if Slug(ref) == book.Slug() {
+			return book
+		}
+	}
+	for _, book := range books {
+		if shortTitle(ref) == shortTitle(book.Title) {
+			return book
+		}
+	}
+	return nil
+}
+
+// Ratings are from maxRating down to 1, the order of Settings.Legend
+func Ratings(maxRating int) []int {
+	ratings := make([]int, maxRating)
+	for i := range ratings {
+		ratings[i] = maxRating - i
+	}
+	return ratings
+}
+
+// RatingMap returns the number of books for each rating, from 1 to maxRating
+func RatingMap(books []*Book, maxRating int) map[int]int {
+	ratingMap := map[int]int{}
+	for rating := 1; rating <= maxRating; rating++ {
+		ratingMap[rating] = 0
+	}
+	for _, book := range books {
+		if book.Rating >= 1 && book.Rating <= maxRating {
+			ratingMap[book.Rating]++
+		}
+	}
+	return ratingMap
+}
@@ -0,0 +1,179 @@
+package reading
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/s12chung/gostatic/go/test"
+)
+
+func TestBook_SortedDate(t *testing.T) {
+	addedAt := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	readAt := time.Date(2018, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	book := &Book{AddedAt: addedAt}
+	test.AssertLabel(t, "Result", book.SortedDate(), addedAt)
+	book.ReadAt = readAt
+	test.AssertLabel(t, "Result", book.SortedDate(), readAt)
+}
+
+func TestBook_Slug(t *testing.T) {
+	testCases := []struct {
+		title string
+		exp   string
+	}{
+		{"Antifragile", "antifragile"},
+		{"The Organization Man: The Book That Defined a Generation", "the-organization-man-the-book-that-defined-a-generation"},
+		{"  Sapiens (2nd Edition)!", "sapiens-2nd-edition"},
+		{"Les Misérables", "les-misérables"},
+	}
+
+	for testCaseIndex, tc := range testCases {
+		context := test.NewContext().SetFields(test.ContextFields{
+			"index": testCaseIndex,
+			"title": tc.title,
+		})
+		book := &Book{Title: tc.title}
+		got := book.Slug()
+		if got != tc.exp {
+			t.Error(context.GotExpString("Result", got, tc.exp))
+		}
+	}
+	test.AssertLabel(t, "URL", (&Book{Title: "Antifragile"}).URL(), "/reading/antifragile")
+}
+
+func TestBook_ReviewHTML(t *testing.T) {
+	book := &Book{Review: " \n"}
+	test.AssertLabel(t, "HasReview", book.HasReview(), false)
+
+	book.Review = "Great look at <b>corporate</b> life.\n\n*Recommended*"
+	test.AssertLabel(t, "HasReview", book.HasReview(), true)
+	test.AssertLabel(t, "ReviewHTML", book.ReviewHTML(), "<p>Great look at <b>corporate</b> life.</p>\n\n<p><em>Recommended</em></p>\n")
+}
+
+func TestReadBooks(t *testing.T) {
+	books := []*Book{{Shelf: ReadShelf}, {Shelf: ToReadShelf}, {Shelf: CurrentlyReadingShelf}, {Shelf: ReadShelf}}
+	got := ReadBooks(books)
+	exp := []*Book{books[0], books[3]}
+	if !cmp.Equal(got, exp) {
+		t.Error(test.NewContext().DiffString("Result", got, exp, cmp.Diff(got, exp)))
+	}
+}
+
+func TestBooksOnShelf(t *testing.T) {
+	books := []*Book{{Shelf: ReadShelf, Shelves: []string{"history"}}, {Shelf: ToReadShelf}, {Shelf: ReadShelf, Shelves: []string{"sociology", "history"}}}
+	testCases := []struct {
+		shelf string
+		exp   []*Book
+	}{
+		{ReadShelf, []*Book{books[0], books[2]}},
+		{ToReadShelf, []*Book{books[1]}},
+		{"history", []*Book{books[0], books[2]}},
+		{"sociology", []*Book{books[2]}},
+		{"fiction", nil},
+	}
+
+	for testCaseIndex, tc := range testCases {
+		context := test.NewContext().SetFields(test.ContextFields{
+			"index": testCaseIndex,
+			"shelf": tc.shelf,
+		})
+		got := BooksOnShelf(books, tc.shelf)
+		if !cmp.Equal(got, tc.exp) {
+			t.Error(context.DiffString("Result", got, tc.exp, cmp.Diff(got, tc.exp)))
+		}
+	}
+}
+
+func TestShelves(t *testing.T) {
+	books := []*Book{{Shelves: []string{"sociology", "history"}}, {}, {Shelves: []string{"history", "fiction"}}}
+	got := Shelves(books)
+	exp := []string{"fiction", "history", "sociology"}
+	if !cmp.Equal(got, exp) {
+		t.Error(test.NewContext().DiffString("Result", got, exp, cmp.Diff(got, exp)))
+	}
+}
+
+func TestFindBook(t *testing.T) {
+	books := []*Book{
+		{Title: "The Organization Man: The Book That Defined a Generation", ISBN: "0812218191", ISBN13: "9780812218190"},
+		{Title: "Sapiens: A Brief History of Humankind"},
+		{Title: "Sapiens"},
+	}
+	testCases := []struct {
+		ref string
+		exp *Book
+	}{
+		{"0812218191", books[0]},
+		{"978-0-8122-1819-0", books[0]},
+		{"The Organization Man", books[0]},
+		{"sapiens", books[2]},
+		{"Sapiens: A Brief History of Humankind", books[1]},
+		{"Antifragile", nil},
+		{"", nil},
+	}
+
+	for testCaseIndex, tc := range testCases {
+		context := test.NewContext().SetFields(test.ContextFields{
+			"index": testCaseIndex,
+			"ref":   tc.ref,
+		})
+		got := FindBook(books, tc.ref)
+		if got != tc.exp {
+			t.Error(context.GotExpString("Result", got, tc.exp))
+		}
+	}
+}
+
+func TestRatings(t *testing.T) {
+	testCases := []struct {
+		maxRating int
+		exp       []int
+	}{
+		{5, []int{5, 4, 3, 2, 1}},
+		{3, []int{3, 2, 1}},
+	}
+
+	for testCaseIndex, tc := range testCases {
+		context := test.NewContext().SetFields(test.ContextFields{
+			"index":     testCaseIndex,
+			"maxRating": tc.maxRating,
+		})
+
+		got := Ratings(tc.maxRating)
+		if !cmp.Equal(got, tc.exp) {
+			t.Error(context.GotExpString("Result", got, tc.exp))
+		}
+	}
+}
+
+func TestRatingMap(t *testing.T) {
+	testCases := []struct {
+		maxRating int
+		ratings   []int
+		exp       map[int]int
+	}{
+		{5, nil, map[int]int{1: 0, 2: 0, 3: 0, 4: 0, 5: 0}},
+		{5, []int{0, 2, 4, 4, 6}, map[int]int{1: 0, 2: 1, 3: 0, 4: 2, 5: 0}},
+		{3, []int{0, 2, 3, 3, 4}, map[int]int{1: 0, 2: 1, 3: 2}},
+	}
+
+	for testCaseIndex, tc := range testCases {
+		context := test.NewContext().SetFields(test.ContextFields{
+			"index":     testCaseIndex,
+			"maxRating": tc.maxRating,
+			"ratings":   tc.ratings,
+		})
+
+		books := make([]*Book, len(tc.ratings))
+		for i, rating := range tc.ratings {
+			books[i] = &Book{Rating: rating}
+		}
+		got := RatingMap(books, tc.maxRating)
+		if !cmp.Equal(got, tc.exp) {
+			t.Error(context.GotExpString("Result", got, tc.exp))
+		}
+	}
+}
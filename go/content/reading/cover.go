@@ -0,0 +1,100 @@
+package reading
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// CoverCache downloads book covers by ISBN and keeps them locally, so they are only downloaded once
+type CoverCache struct {
+	settings *Settings
+	client   *http.Client
+	log      logrus.FieldLogger
+}
+
+func NewCoverCache(settings *Settings, log logrus.FieldLogger) *CoverCache {
+	return &CoverCache{
+		settings,
+		&http.Client{Timeout: time.Duration(settings.TimeoutSeconds) * time.Second},
+		log,
+	}
+}
+
+// CoverURL is the URL of the book's cover, served from the cache
+func CoverURL(book *Book) string {
+	return fmt.Sprintf("%v/covers/%v.jpg", URL, book.Slug())
+}
+
+func (cache *CoverCache) filePath(book *Book) string {
+	return path.Join(cache.settings.CoverCachePath, book.Slug()+".jpg")
+}
+
+// Path returns the file path of the cached cover, downloading it if needed.
+// It returns "" when the book has no cover, covers that could not be found are cached as empty files.
+func (cache *CoverCache) Path(book *Book) (string, error) {
+	isbn := book.ISBN13
+	if isbn == "" {
+		isbn = book.ISBN
+	}
+	if isbn == "" {
+		return "", nil
+	}
+
+	filePath := cache.filePath(book)
+	info, err := os.Stat(filePath)
+	if err == nil {
+		if info.Size() == 0 {
+			return "", nil
+		}
+		return filePath, nil
+	}
+	if !os.IsNotExist(err) {
+		return "", err
+	}
+
+	bytes, err := cache.download(isbn)
+	if err != nil {
+		cache.log.Warnf("could not download the cover of %v: %v", book.Title, err)
+		return "", nil
+	}
+	err = os.MkdirAll(cache.settings.CoverCachePath, 0755)
+	if err != nil {
+		return "", err
+	}
+	err = ioutil.WriteFile(filePath, bytes, 0644)
+	if err != nil {
+		return "", err
+	}
+	if len(bytes) == 0 {
+		return "", nil
+	}
+	return filePath, nil
+}
+
+// download returns no bytes when the cover is not found
+func (cache *CoverCache) download(isbn string) ([]byte, error) {
+	response, err := cache.client.Get(fmt.Sprintf(cache.settings.CoverURL, isbn))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err := response.Body.Close()
+		if err != nil {
+			cache.log.Error(err)
+		}
+	}()
+
+	if response.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%v response status", response.StatusCode)
+	}
+	return ioutil.ReadAll(response.Body)
+}
@@ -0,0 +1,73 @@
+package reading
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"testing"
+
+	logTest "github.com/sirupsen/logrus/hooks/test"
+
+	"github.com/s12chung/gostatic/go/test"
+)
+
+func TestCoverURL(t *testing.T) {
+	test.AssertLabel(t, "Result", CoverURL(&Book{Title: "Antifragile"}), "/reading/covers/antifragile.jpg")
+}
+
+func TestCoverCache_Path(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/9780812218190.jpg" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := w.Write([]byte("cover"))
+		if err != nil {
+			t.Error(err)
+		}
+	}))
+	defer server.Close()
+
+	settings := DefaultSettings()
+	cachePath, clean := test.SandboxDir(t, settings.CoverCachePath)
+	defer clean()
+	settings.CoverCachePath = cachePath
+	settings.CoverURL = server.URL + "/%v.jpg"
+
+	log, _ := logTest.NewNullLogger()
+	cache := NewCoverCache(settings, log)
+
+	testCases := []struct {
+		book        *Book
+		exp         string
+		expRequests int
+	}{
+		{&Book{Title: "No ISBN"}, "", 0},
+		{&Book{Title: "Sapiens", ISBN: "0062316095"}, "", 1},
+		{&Book{Title: "The Organization Man", ISBN: "0812218191", ISBN13: "9780812218190"}, path.Join(cachePath, "the-organization-man.jpg"), 1},
+	}
+
+	for testCaseIndex, tc := range testCases {
+		context := test.NewContext().SetFields(test.ContextFields{
+			"index": testCaseIndex,
+			"title": tc.book.Title,
+		})
+
+		// the second time is from the cache
+		for _, expRequests := range []int{tc.expRequests, 0} {
+			requests = 0
+			got, err := cache.Path(tc.book)
+			if err != nil {
+				t.Error(context.String(err))
+			}
+			if got != tc.exp {
+				t.Error(context.GotExpString("Result", got, tc.exp))
+			}
+			if requests != expRequests {
+				t.Error(context.GotExpString("requests", requests, expRequests))
+			}
+		}
+	}
+}
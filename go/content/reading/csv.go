@@ -0,0 +1,104 @@
+package reading
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func csvBooks(filePath string, csvBook func(header csvHeader, record []string) (*Book, error)) ([]*Book, error) {
+	var books []*Book
+	err := readCSV(filePath, func(header csvHeader, record []string) error {
+		book, err := csvBook(header, record)
+		if err != nil {
+			return err
+		}
+		books = append(books, book)
+		return nil
+	})
+	return books, err
+}
+
+// readCSV calls f with each record after the header
+func readCSV(filePath string, f func(header csvHeader, record []string) error) error {
+	bytes, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	records, err := csv.NewReader(strings.NewReader(string(bytes))).ReadAll()
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return nil
+	}
+
+	header := newCSVHeader(records[0])
+	for i, record := range records[1:] {
+		err = f(header, record)
+		if err != nil {
+			return fmt.Errorf("%v, line %v: %v", filePath, i+2, err)
+		}
+	}
+	return nil
+}
+
+type csvHeader map[string]int
+
+func newCSVHeader(record []string) csvHeader {
+	header := csvHeader{}
+	for i, column := range record {
+		header[strings.TrimSpace(column)] = i
+	}
+	return header
+}
+
+func (header csvHeader) get(record []string, column string) string {
+	i, exists := header[column]
+	if !exists || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+func splitList(s string) []string {
+	var list []string
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func parseInt(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(s)
+}
+
+func parseDate(layout, s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(layout, s)
+}
+
+// parseRating rounds ratings with fractions, like 4.5
+func parseRating(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+	rating, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+	return int(math.Round(rating)), nil
+}
@@ -0,0 +1,140 @@
+package reading
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/s12chung/gostatic-packages/goodreads"
+)
+
+// the last successful book list, saved in the goodreads cache_path
+const goodreadsFallbackFilename = "reading_books.json"
+
+type goodreadsAPISource struct {
+	settings       *goodreads.Settings
+	failOnFallback bool
+	log            logrus.FieldLogger
+}
+
+// Books gets the read books from the Goodreads API, falling back to the last successful book list on errors
+func (source *goodreadsAPISource) Books() ([]*Book, error) {
+	return source.fallback(source.apiBooks())
+}
+
+func (source *goodreadsAPISource) fallbackPath() string {
+	return path.Join(source.settings.CachePath, goodreadsFallbackFilename)
+}
+
+func (source *goodreadsAPISource) fallback(books []*Book, apiErr error) ([]*Book, error) {
+	if apiErr == nil {
+		return books, source.saveFallback(books)
+	}
+	if source.failOnFallback {
+		return nil, fmt.Errorf("goodreads request failed and fail_on_fallback is set: %v", apiErr)
+	}
+
+	bytes, err := ioutil.ReadFile(source.fallbackPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, apiErr
+		}
+		return nil, err
+	}
+	err = json.Unmarshal(bytes, &books)
+	if err != nil {
+		return nil, err
+	}
+	source.log.Warnf("goodreads request failed, using the last successful book list at %v: %v", source.fallbackPath(), apiErr)
+	return books, nil
+}
+
+func (source *goodreadsAPISource) saveFallback(books []*Book) error {
+	bytes, err := json.Marshal(books)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(source.settings.CachePath, 0755)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(source.fallbackPath(), bytes, 0644)
+}
+
+func (source *goodreadsAPISource) apiBooks() ([]*Book, error) {
+	goodreadsBooks, err := goodreads.NewClient(source.settings, source.log).GetBooks()
+	if err != nil {
+		return nil, err
+	}
+
+	books := make([]*Book, len(goodreadsBooks))
+	for i, goodreadsBook := range goodreadsBooks {
+		books[i] = goodreadsAPIBook(goodreadsBook)
+	}
+	return books, nil
+}
+
+// goodreadsAPIBook converts a book of the Goodreads client, which only has the title, authors, rating and read date.
+// So API books have no ID, ISBN or review, which means no covers and no review pages, the goodreads_csv source has them
+func goodreadsAPIBook(goodreadsBook *goodreads.Book) *Book {
+	return &Book{
+		Title:   goodreadsBook.Title,
+		Authors: goodreadsBook.Authors,
+		Rating:  goodreadsBook.Rating,
+		Shelf:   ReadShelf,
+		ReadAt:  goodreadsBook.SortedDate(),
+	}
+}
+
+const goodreadsCSVDateFormat = "2006/01/02"
+
+type goodreadsCSVSource struct {
+	filePath string
+}
+
+// Books parses the Goodreads library export, see https://www.goodreads.com/review/import
+func (source *goodreadsCSVSource) Books() ([]*Book, error) {
+	return csvBooks(source.filePath, goodreadsCSVBook)
+}
+
+func goodreadsCSVBook(header csvHeader, record []string) (*Book, error) {
+	book := &Book{
+		ID:      header.get(record, "Book Id"),
+		Title:   header.get(record, "Title"),
+		Authors: splitList(header.get(record, "Author") + "," + header.get(record, "Additional Authors")),
+		ISBN:    goodreadsISBN(header.get(record, "ISBN")),
+		ISBN13:  goodreadsISBN(header.get(record, "ISBN13")),
+		Shelf:   header.get(record, "Exclusive Shelf"),
+		Shelves: splitList(header.get(record, "Bookshelves")),
+		Review:  header.get(record, "My Review"),
+	}
+
+	var err error
+	book.Rating, err = parseInt(header.get(record, "My Rating"))
+	if err != nil {
+		return nil, err
+	}
+	book.Pages, err = parseInt(header.get(record, "Number of Pages"))
+	if err != nil {
+		return nil, err
+	}
+	book.AddedAt, err = parseDate(goodreadsCSVDateFormat, header.get(record, "Date Added"))
+	if err != nil {
+		return nil, err
+	}
+	book.ReadAt, err = parseDate(goodreadsCSVDateFormat, header.get(record, "Date Read"))
+	if err != nil {
+		return nil, err
+	}
+	return book, nil
+}
+
+// goodreadsISBN removes the spreadsheet formula quoting: ="0812218191"
+func goodreadsISBN(s string) string {
+	return strings.Trim(s, `="`)
+}
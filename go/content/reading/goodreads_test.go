@@ -0,0 +1,124 @@
+package reading
+
+import (
+	"fmt"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+
+	"github.com/s12chung/gostatic-packages/goodreads"
+	"github.com/s12chung/gostatic/go/test"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestGoodreadsCSVSource_Books(t *testing.T) {
+	books, err := (&goodreadsCSVSource{path.Join(test.FixturePath, "goodreads_library_export.csv")}).Books()
+	if err != nil {
+		t.Error(err)
+	}
+
+	exp := []*Book{
+		{
+			ID:      "725027",
+			Title:   "The Organization Man: The Book That Defined a Generation",
+			Authors: []string{"William H. Whyte", "Carlota Pérez"},
+			ISBN:    "0812218191",
+			ISBN13:  "9780812218190",
+			Rating:  4,
+			Pages:   448,
+			Shelf:   ReadShelf,
+			Shelves: []string{"history", "sociology"},
+			AddedAt: date(2018, 6, 1),
+			ReadAt:  date(2018, 7, 15),
+			Review:  "Great look at <b>corporate</b> life.",
+		},
+		{
+			ID:      "18505796",
+			Title:   "Antifragile",
+			Authors: []string{"Nassim Nicholas Taleb"},
+			Rating:  2,
+			Pages:   519,
+			Shelf:   ReadShelf,
+			AddedAt: date(2010, 3, 4),
+		},
+		{
+			ID:      "23692271",
+			Title:   "Sapiens",
+			Authors: []string{"Yuval Noah Harari"},
+			ISBN:    "0062316095",
+			ISBN13:  "9780062316097",
+			Pages:   443,
+			Shelf:   CurrentlyReadingShelf,
+			AddedAt: date(2018, 9, 1),
+		},
+	}
+	if !cmp.Equal(books, exp) {
+		t.Error(test.NewContext().DiffString("Result", books, exp, cmp.Diff(books, exp)))
+	}
+
+	_, err = (&goodreadsCSVSource{path.Join(test.FixturePath, "does_not_exist.csv")}).Books()
+	if err == nil {
+		t.Error("no error for missing file")
+	}
+}
+
+func TestGoodreadsAPIBook(t *testing.T) {
+	got := goodreadsAPIBook(&goodreads.Book{Title: "Antifragile", Authors: []string{"Nassim Nicholas Taleb"}, Rating: 2})
+	exp := &Book{Title: "Antifragile", Authors: []string{"Nassim Nicholas Taleb"}, Rating: 2, Shelf: ReadShelf}
+	if !cmp.Equal(got, exp) {
+		t.Error(test.NewContext().DiffString("goodreadsAPIBook", got, exp, cmp.Diff(got, exp)))
+	}
+	// the Goodreads client does not have them
+	if got.ID != "" || got.ISBN != "" || got.ISBN13 != "" || got.Review != "" {
+		t.Error("API book has an ID, ISBN or review")
+	}
+}
+
+func TestGoodreadsAPISource_fallback(t *testing.T) {
+	settings := goodreads.DefaultSettings()
+	cachePath, clean := test.SandboxDir(t, settings.CachePath)
+	defer clean()
+	settings.CachePath = cachePath
+
+	log, hook := logTest.NewNullLogger()
+	source := &goodreadsAPISource{settings, false, log}
+	apiErr := fmt.Errorf("rate limited")
+
+	_, err := source.fallback(nil, apiErr)
+	if err != apiErr {
+		t.Error(test.NewContext().GotExpString("err without fallback", err, apiErr))
+	}
+
+	exp := []*Book{{Title: "Antifragile", Authors: []string{"Nassim Nicholas Taleb"}, Rating: 2, Shelf: ReadShelf, ReadAt: date(2010, 3, 4)}}
+	books, err := source.fallback(exp, nil)
+	if err != nil {
+		t.Error(err)
+	}
+	if !cmp.Equal(books, exp) {
+		t.Error(test.NewContext().DiffString("books", books, exp, cmp.Diff(books, exp)))
+	}
+
+	books, err = source.fallback(nil, apiErr)
+	if err != nil {
+		t.Error(err)
+	}
+	if !cmp.Equal(books, exp) {
+		t.Error(test.NewContext().DiffString("fallback books", books, exp, cmp.Diff(books, exp)))
+	}
+	if len(hook.Entries) != 1 || hook.LastEntry().Level != logrus.WarnLevel {
+		t.Errorf("expected a warning to be logged, got: %v", hook.Entries)
+	}
+
+	source.failOnFallback = true
+	_, err = source.fallback(nil, apiErr)
+	if err == nil {
+		t.Error("no error with failOnFallback")
+	}
+}
@@ -0,0 +1,234 @@
+package reading
+
+import (
+	"io/ioutil"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Highlight is a passage highlighted in a book, with an optional note
+type Highlight struct {
+	Title    string
+	Author   string
+	Text     string
+	Note     string
+	Location string
+	AddedAt  time.Time
+}
+
+// BookHighlights are the highlights of a book, Book is nil when the book is not in the book list
+type BookHighlights struct {
+	Title      string
+	Author     string
+	Book       *Book
+	Highlights []*Highlight
+}
+
+// Slug is used to link to the highlights of the book
+func (bookHighlights *BookHighlights) Slug() string {
+	return Slug(bookHighlights.Title)
+}
+
+// Highlights reads the highlights of the Kindle clippings and Readwise export set in settings, missing files are skipped
+func Highlights(settings *Settings, log logrus.FieldLogger) ([]*Highlight, error) {
+	var highlights []*Highlight
+	sources := []struct {
+		filePath string
+		read     func(filePath string) ([]*Highlight, error)
+	}{
+		{settings.KindleClippingsPath, func(filePath string) ([]*Highlight, error) { return KindleClippings(filePath, log) }},
+		{settings.ReadwiseCSVPath, ReadwiseCSVHighlights},
+	}
+	for _, source := range sources {
+		if source.filePath == "" {
+			continue
+		}
+		if _, err := os.Stat(source.filePath); os.IsNotExist(err) {
+			continue
+		}
+		sourceHighlights, err := source.read(source.filePath)
+		if err != nil {
+			return nil, err
+		}
+		highlights = append(highlights, sourceHighlights...)
+	}
+	return highlights, nil
+}
+
+const kindleClippingSeparator = "=========="
+const kindleDateFormat = "Monday, January 2, 2006 3:04:05 PM"
+
+var kindleTitleRegex = regexp.MustCompile(`^(.*?)\s*\(([^()]*)\)$`)
+var kindleMetaRegex = regexp.MustCompile(`^- (?:Your )?(Highlight|Note|Bookmark)\b(.*?)\|\s*Added on (.*)$`)
+var kindleLocationRegex = regexp.MustCompile(`(?i)(?:location|loc\.)\s*([\d-]+)`)
+
+// KindleClippings parses a Kindle "My Clippings.txt" file, notes are attached to the highlight at the same location.
+// Clippings that can not be parsed, like from Kindles in other languages, are logged and skipped
+func KindleClippings(filePath string, log logrus.FieldLogger) ([]*Highlight, error) {
+	bytes, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var highlights []*Highlight
+	// Kindles write a byte order mark before each clipping
+	content := strings.Replace(strings.Replace(string(bytes), "\r\n", "\n", -1), "\ufeff", "", -1)
+	for i, clipping := range strings.Split(content, kindleClippingSeparator) {
+		lines := strings.Split(strings.TrimSpace(clipping), "\n")
+		if len(lines) < 2 {
+			continue
+		}
+
+		meta := kindleMetaRegex.FindStringSubmatch(strings.TrimSpace(lines[1]))
+		if meta == nil {
+			log.Warnf("Skipping %v, clipping %v: can not parse: %v", filePath, i+1, lines[1])
+			continue
+		}
+		kind := meta[1]
+		text := strings.TrimSpace(strings.Join(lines[2:], "\n"))
+		if kind == "Bookmark" || text == "" {
+			continue
+		}
+
+		highlight := &Highlight{}
+		highlight.Title, highlight.Author = kindleTitle(strings.TrimSpace(lines[0]))
+		location := kindleLocationRegex.FindStringSubmatch(meta[2])
+		if location != nil {
+			highlight.Location = location[1]
+		}
+		highlight.AddedAt, err = time.Parse(kindleDateFormat, strings.TrimSpace(meta[3]))
+		if err != nil {
+			log.Warnf("Skipping %v, clipping %v: %v", filePath, i+1, err)
+			continue
+		}
+
+		if kind == "Note" {
+			previous := lastHighlight(highlights, highlight)
+			if previous != nil {
+				previous.Note = text
+				continue
+			}
+			highlight.Note = text
+		} else {
+			highlight.Text = text
+		}
+		highlights = append(highlights, highlight)
+	}
+	return highlights, nil
+}
+
+// kindleTitle splits "Title (Author)"
+func kindleTitle(line string) (string, string) {
+	match := kindleTitleRegex.FindStringSubmatch(line)
+	if match == nil {
+		return line, ""
+	}
+	return match[1], match[2]
+}
+
+// lastHighlight returns the last highlight if it is from the same book and location, as Kindle notes follow their highlights
+func lastHighlight(highlights []*Highlight, note *Highlight) *Highlight {
+	if len(highlights) == 0 {
+		return nil
+	}
+	last := highlights[len(highlights)-1]
+	if last.Title != note.Title || last.Note != "" {
+		return nil
+	}
+	lastLocation := strings.Split(last.Location, "-")
+	if note.Location != lastLocation[len(lastLocation)-1] && note.Location != last.Location {
+		return nil
+	}
+	return last
+}
+
+const readwiseDateFormat = "2006-01-02 15:04:05-07:00"
+
+// ReadwiseCSVHighlights parses the Readwise CSV export, which also has the highlights synced from Kobo
+func ReadwiseCSVHighlights(filePath string) ([]*Highlight, error) {
+	var highlights []*Highlight
+	err := readCSV(filePath, func(header csvHeader, record []string) error {
+		highlight := &Highlight{
+			Title:    header.get(record, "Book Title"),
+			Author:   header.get(record, "Book Author"),
+			Text:     header.get(record, "Highlight"),
+			Note:     header.get(record, "Note"),
+			Location: header.get(record, "Location"),
+		}
+		var err error
+		highlight.AddedAt, err = parseDate(readwiseDateFormat, header.get(record, "Highlighted at"))
+		if err != nil {
+			return err
+		}
+		highlights = append(highlights, highlight)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return highlights, nil
+}
+
+// GroupHighlights groups the highlights by book and matches them to books by title and author,
+// groups are sorted by title and highlights keep their order
+func GroupHighlights(highlights []*Highlight, books []*Book) []*BookHighlights {
+	var groups []*BookHighlights
+	groupMap := map[string]*BookHighlights{}
+	for _, highlight := range highlights {
+		key := shortTitle(highlight.Title)
+		group, exists := groupMap[key]
+		if !exists {
+			group = &BookHighlights{
+				Title:  highlight.Title,
+				Author: highlight.Author,
+				Book:   matchBook(books, highlight.Title, highlight.Author),
+			}
+			if group.Book != nil {
+				group.Title = group.Book.Title
+			}
+			groupMap[key] = group
+			groups = append(groups, group)
+		}
+		group.Highlights = append(group.Highlights, highlight)
+	}
+	sort.SliceStable(groups, func(i, j int) bool { return groups[i].Title < groups[j].Title })
+	return groups
+}
+
+// shortTitle is the slug of the title without the subtitle, which differs between sources
+func shortTitle(title string) string {
+	title = strings.SplitN(title, ":", 2)[0]
+	title = strings.SplitN(title, "(", 2)[0]
+	return Slug(title)
+}
+
+// matchBook returns the book with the same short title, checking that an author's name matches when author is given
+func matchBook(books []*Book, title, author string) *Book {
+	key := shortTitle(title)
+	authorWords := map[string]bool{}
+	for _, word := range strings.Split(Slug(author), "-") {
+		authorWords[word] = true
+	}
+
+	for _, book := range books {
+		if shortTitle(book.Title) != key {
+			continue
+		}
+		if author == "" {
+			return book
+		}
+		for _, bookAuthor := range book.Authors {
+			words := strings.Split(Slug(bookAuthor), "-")
+			// the last name, as sources format names differently: "Whyte, William H." or "William H. Whyte"
+			if authorWords[words[len(words)-1]] {
+				return book
+			}
+		}
+	}
+	return nil
+}
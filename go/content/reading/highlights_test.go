@@ -0,0 +1,112 @@
+package reading
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+
+	"github.com/s12chung/gostatic/go/test"
+)
+
+var testOrganizationManHighlight = &Highlight{
+	Title:    "The Organization Man: The Book That Defined a Generation",
+	Author:   "Whyte, William H.",
+	Text:     "The organization man is not the worker, nor is he the white-collar man in the usual, clerk sense of the word.",
+	Note:     "Still true today.",
+	Location: "180-182",
+	AddedAt:  time.Date(2018, 7, 15, 22, 11, 12, 0, time.UTC),
+}
+
+var testUnreadHighlight = &Highlight{
+	Title:    "Unread Book",
+	Author:   "Someone Else",
+	Text:     "A passage.",
+	Location: "40-41",
+	AddedAt:  time.Date(2018, 8, 6, 8, 0, 0, 0, time.UTC),
+}
+
+var testSapiensHighlight = &Highlight{
+	Title:    "Sapiens: A Brief History of Humankind",
+	Author:   "Yuval Noah Harari",
+	Text:     "We are a species of storytellers.",
+	Note:     "Kobo highlight",
+	Location: "1200",
+	AddedAt:  time.Date(2018, 9, 2, 10, 0, 0, 0, time.FixedZone("", 0)),
+}
+
+func TestKindleClippings(t *testing.T) {
+	log, hook := logTest.NewNullLogger()
+	got, err := KindleClippings(path.Join(test.FixturePath, "My Clippings.txt"), log)
+	if err != nil {
+		t.Error(err)
+	}
+	exp := []*Highlight{testOrganizationManHighlight, testUnreadHighlight}
+	if !cmp.Equal(got, exp) {
+		t.Error(test.NewContext().DiffString("Result", got, exp, cmp.Diff(got, exp)))
+	}
+
+	// the German clipping and the clipping with an unknown date format are skipped
+	if len(hook.Entries) != 2 {
+		t.Errorf("expected 2 warnings for the skipped clippings, got: %v", hook.Entries)
+	}
+	for _, entry := range hook.Entries {
+		if entry.Level != logrus.WarnLevel {
+			t.Error(test.NewContext().GotExpString("entry.Level", entry.Level, logrus.WarnLevel))
+		}
+	}
+}
+
+func TestReadwiseCSVHighlights(t *testing.T) {
+	got, err := ReadwiseCSVHighlights(path.Join(test.FixturePath, "readwise_export.csv"))
+	if err != nil {
+		t.Error(err)
+	}
+	exp := []*Highlight{testSapiensHighlight}
+	if !cmp.Equal(got, exp) {
+		t.Error(test.NewContext().DiffString("Result", got, exp, cmp.Diff(got, exp)))
+	}
+}
+
+func TestHighlights(t *testing.T) {
+	settings := DefaultSettings()
+	settings.KindleClippingsPath = path.Join(test.FixturePath, "My Clippings.txt")
+	settings.ReadwiseCSVPath = path.Join(test.FixturePath, "readwise_export.csv")
+	log, _ := logTest.NewNullLogger()
+	got, err := Highlights(settings, log)
+	if err != nil {
+		t.Error(err)
+	}
+	exp := []*Highlight{testOrganizationManHighlight, testUnreadHighlight, testSapiensHighlight}
+	if !cmp.Equal(got, exp) {
+		t.Error(test.NewContext().DiffString("Result", got, exp, cmp.Diff(got, exp)))
+	}
+
+	settings.KindleClippingsPath = path.Join(test.FixturePath, "does_not_exist.txt")
+	settings.ReadwiseCSVPath = ""
+	got, err = Highlights(settings, log)
+	if err != nil || len(got) != 0 {
+		t.Errorf("missing files are not skipped, got: %v, err: %v", got, err)
+	}
+}
+
+func TestGroupHighlights(t *testing.T) {
+	books := []*Book{
+		{Title: "The Organization Man", Authors: []string{"William H. Whyte"}},
+		{Title: "Sapiens", Authors: []string{"Someone Else"}},
+	}
+	highlights := []*Highlight{testOrganizationManHighlight, testUnreadHighlight, testSapiensHighlight, testOrganizationManHighlight}
+
+	got := GroupHighlights(highlights, books)
+	exp := []*BookHighlights{
+		{"Sapiens: A Brief History of Humankind", "Yuval Noah Harari", nil, []*Highlight{testSapiensHighlight}},
+		{"The Organization Man", "Whyte, William H.", books[0], []*Highlight{testOrganizationManHighlight, testOrganizationManHighlight}},
+		{"Unread Book", "Someone Else", nil, []*Highlight{testUnreadHighlight}},
+	}
+	if !cmp.Equal(got, exp) {
+		t.Error(test.NewContext().DiffString("Result", got, exp, cmp.Diff(got, exp)))
+	}
+}
@@ -0,0 +1,83 @@
+package reading
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+)
+
+const openLibraryDateFormat = "2006/01/02, 15:04:05"
+
+// the files of the reading log, named like the API urls: https://openlibrary.org/people/<username>/books/already-read.json
+var openLibraryShelfFiles = map[string]string{
+	ReadShelf:             "already-read.json",
+	CurrentlyReadingShelf: "currently-reading.json",
+	ToReadShelf:           "want-to-read.json",
+}
+
+type openLibrarySource struct {
+	dirPath string
+}
+
+type openLibraryReadingLog struct {
+	Entries []*openLibraryEntry `json:"reading_log_entries"`
+}
+
+type openLibraryEntry struct {
+	Work struct {
+		Key         string   `json:"key"`
+		Title       string   `json:"title"`
+		AuthorNames []string `json:"author_names"`
+	} `json:"work"`
+	LoggedDate string `json:"logged_date"`
+}
+
+// Books parses the reading log JSON files in the directory, missing files are skipped
+func (source *openLibrarySource) Books() ([]*Book, error) {
+	var books []*Book
+	for _, shelf := range []string{ReadShelf, CurrentlyReadingShelf, ToReadShelf} {
+		shelfBooks, err := openLibraryBooks(path.Join(source.dirPath, openLibraryShelfFiles[shelf]), shelf)
+		if err != nil {
+			return nil, err
+		}
+		books = append(books, shelfBooks...)
+	}
+	return books, nil
+}
+
+func openLibraryBooks(filePath, shelf string) ([]*Book, error) {
+	bytes, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	readingLog := &openLibraryReadingLog{}
+	err = json.Unmarshal(bytes, readingLog)
+	if err != nil {
+		return nil, err
+	}
+
+	books := make([]*Book, len(readingLog.Entries))
+	for i, entry := range readingLog.Entries {
+		loggedAt, err := parseDate(openLibraryDateFormat, entry.LoggedDate)
+		if err != nil {
+			return nil, err
+		}
+		books[i] = &Book{
+			ID:      strings.TrimPrefix(entry.Work.Key, "/works/"),
+			Title:   entry.Work.Title,
+			Authors: entry.Work.AuthorNames,
+			Shelf:   shelf,
+			AddedAt: loggedAt,
+		}
+		if shelf == ReadShelf {
+			books[i].ReadAt = loggedAt
+		}
+	}
+	return books, nil
+}
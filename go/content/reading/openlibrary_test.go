@@ -0,0 +1,40 @@
+package reading
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/s12chung/gostatic/go/test"
+)
+
+func TestOpenLibrarySource_Books(t *testing.T) {
+	books, err := (&openLibrarySource{path.Join(test.FixturePath, "openlibrary")}).Books()
+	if err != nil {
+		t.Error(err)
+	}
+
+	readAt := time.Date(2018, 7, 15, 10, 11, 12, 0, time.UTC)
+	exp := []*Book{
+		{
+			ID:      "OL1919816W",
+			Title:   "The Organization Man",
+			Authors: []string{"William H. Whyte"},
+			Shelf:   ReadShelf,
+			AddedAt: readAt,
+			ReadAt:  readAt,
+		},
+		{
+			ID:      "OL17075811W",
+			Title:   "Sapiens",
+			Authors: []string{"Yuval Noah Harari"},
+			Shelf:   ToReadShelf,
+			AddedAt: time.Date(2018, 9, 1, 8, 0, 0, 0, time.UTC),
+		},
+	}
+	if !cmp.Equal(books, exp) {
+		t.Error(test.NewContext().DiffString("Result", books, exp, cmp.Diff(books, exp)))
+	}
+}
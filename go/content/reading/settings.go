@@ -0,0 +1,77 @@
+package reading
+
+import "fmt"
+
+// Sources of the book list, set in Settings.Source
+const (
+	GoodreadsSource     = "goodreads"
+	GoodreadsCSVSource  = "goodreads_csv"
+	StoryGraphCSVSource = "storygraph_csv"
+	OpenLibrarySource   = "openlibrary_json"
+	YAMLSource          = "yaml"
+)
+
+type Settings struct {
+	Source            string `json:"source,omitempty"`
+	GoodreadsCSVPath  string `json:"goodreads_csv_path,omitempty"`
+	StoryGraphCSVPath string `json:"storygraph_csv_path,omitempty"`
+	OpenLibraryPath   string `json:"openlibrary_path,omitempty"`
+	YAMLPath          string `json:"yaml_path,omitempty"`
+
+	// Legend describes each rating, from the highest rating down to 1 star, its length is the rating scale
+	Legend []string `json:"legend,omitempty"`
+	// ExtraShelves are exclusive shelves shown as sections after the read books: currently-reading or to-read
+	ExtraShelves []string `json:"extra_shelves,omitempty"`
+	// ShelfPages generates a reading page for each shelf of the read books
+	ShelfPages bool `json:"shelf_pages,omitempty"`
+
+	// KindleClippingsPath and ReadwiseCSVPath are the highlights of the books, missing files are skipped
+	KindleClippingsPath string `json:"kindle_clippings_path,omitempty"`
+	ReadwiseCSVPath     string `json:"readwise_csv_path,omitempty"`
+
+	// CoverURL is formatted with the book's ISBN to download its cover, covers are cached in CoverCachePath
+	CoverURL       string `json:"cover_url,omitempty"`
+	CoverCachePath string `json:"cover_cache_path,omitempty"`
+	TimeoutSeconds int    `json:"timeout_seconds,omitempty"`
+
+	// FailOnFallback errors instead of using the last successful Goodreads book list, for CI
+	FailOnFallback bool `json:"fail_on_fallback,omitempty"`
+}
+
+func DefaultSettings() *Settings {
+	return &Settings{
+		GoodreadsSource,
+		"./content/data/goodreads_library_export.csv",
+		"./content/data/storygraph_export.csv",
+		"./content/data/openlibrary",
+		"./content/data/books.yml",
+		[]string{
+			"Great to priceless ideas or fun to read, no major flaws.",
+			"Good to priceless ideas and may also have flaws—hard to read, filler, etc.",
+			"Good to great ideas and may have the flaws stated above.",
+			"Few good ideas. I probably skimmed through it.",
+			"Waste of time.",
+		},
+		nil,
+		false,
+		"./content/data/My Clippings.txt",
+		"./content/data/readwise_export.csv",
+		"https://covers.openlibrary.org/b/isbn/%v-M.jpg?default=false",
+		"./cache/covers",
+		10,
+		false,
+	}
+}
+
+// MaxRating is the highest rating of the scale, set by the number of Legend descriptions
+func (settings *Settings) MaxRating() int {
+	return len(settings.Legend)
+}
+
+// Validate checks that the Legend describes at least one rating
+func (settings *Settings) Validate() error {
+	if len(settings.Legend) == 0 {
+		return fmt.Errorf("the reading legend is empty, it needs a description for each rating from the highest down to 1 star")
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package reading
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/s12chung/gostatic-packages/goodreads"
+)
+
+// Source is where the book list comes from
+type Source interface {
+	Books() ([]*Book, error)
+}
+
+// NewSource returns the Source set by settings.Source
+func NewSource(settings *Settings, goodreadsSettings *goodreads.Settings, log logrus.FieldLogger) (Source, error) {
+	err := settings.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	switch settings.Source {
+	case GoodreadsSource:
+		return &goodreadsAPISource{goodreadsSettings, settings.FailOnFallback, log}, nil
+	case GoodreadsCSVSource:
+		return &goodreadsCSVSource{settings.GoodreadsCSVPath}, nil
+	case StoryGraphCSVSource:
+		return &storyGraphCSVSource{settings.StoryGraphCSVPath}, nil
+	case OpenLibrarySource:
+		return &openLibrarySource{settings.OpenLibraryPath}, nil
+	case YAMLSource:
+		return &yamlSource{settings.YAMLPath}, nil
+	}
+	return nil, fmt.Errorf("unknown reading source: %v", settings.Source)
+}
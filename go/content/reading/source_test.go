@@ -0,0 +1,80 @@
+package reading
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/s12chung/gostatic-packages/goodreads"
+	"github.com/s12chung/gostatic/go/test"
+)
+
+func TestNewSource(t *testing.T) {
+	testCases := []struct {
+		source  string
+		expType Source
+	}{
+		{GoodreadsSource, &goodreadsAPISource{}},
+		{GoodreadsCSVSource, &goodreadsCSVSource{}},
+		{StoryGraphCSVSource, &storyGraphCSVSource{}},
+		{OpenLibrarySource, &openLibrarySource{}},
+		{YAMLSource, &yamlSource{}},
+		{"unknown", nil},
+	}
+
+	for testCaseIndex, tc := range testCases {
+		context := test.NewContext().SetFields(test.ContextFields{
+			"index":  testCaseIndex,
+			"source": tc.source,
+		})
+
+		settings := DefaultSettings()
+		settings.Source = tc.source
+		source, err := NewSource(settings, &goodreads.Settings{}, logrus.New())
+		if tc.expType == nil {
+			if err == nil {
+				t.Error(context.String("no error for unknown source"))
+			}
+			continue
+		}
+		if err != nil {
+			t.Error(context.String(err))
+			continue
+		}
+
+		got, exp := reflect.TypeOf(source), reflect.TypeOf(tc.expType)
+		if got != exp {
+			t.Error(context.GotExpString("type", got, exp))
+		}
+	}
+}
+
+func TestNewSource_Legend(t *testing.T) {
+	testCases := []struct {
+		legend []string
+		valid  bool
+	}{
+		{DefaultSettings().Legend, true},
+		{[]string{"Great.", "Good.", "Bad."}, true},
+		{[]string{}, false},
+		{nil, false},
+	}
+
+	for testCaseIndex, tc := range testCases {
+		context := test.NewContext().SetFields(test.ContextFields{
+			"index":  testCaseIndex,
+			"legend": tc.legend,
+		})
+
+		settings := DefaultSettings()
+		settings.Legend = tc.legend
+		_, err := NewSource(settings, &goodreads.Settings{}, logrus.New())
+		if tc.valid && err != nil {
+			t.Error(context.String(err))
+		}
+		if !tc.valid && err == nil {
+			t.Error(context.String("no error for an empty legend"))
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package reading
+
+import (
+	"sort"
+)
+
+// YearStats are the statistics of the books read in a year
+type YearStats struct {
+	Year      int `json:"year"`
+	BooksRead int `json:"books_read"`
+	PagesRead int `json:"pages_read"`
+
+	// AverageRating is the average of the rated books, 0 if there are none
+	AverageRating float64     `json:"average_rating"`
+	RatingMap     map[int]int `json:"rating_map"`
+
+	// RepeatAuthors are the authors with more than one book read in the year, sorted by name
+	RepeatAuthors []string `json:"repeat_authors"`
+}
+
+// YearlyStats returns the statistics of the read books for each year, newest year first, rated up to maxRating
+func YearlyStats(books []*Book, maxRating int) []*YearStats {
+	yearBooks := map[int][]*Book{}
+	for _, book := range ReadBooks(books) {
+		year := book.SortedDate().Year()
+		yearBooks[year] = append(yearBooks[year], book)
+	}
+
+	stats := make([]*YearStats, 0, len(yearBooks))
+	for year, books := range yearBooks {
+		stats = append(stats, newYearStats(year, books, maxRating))
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Year > stats[j].Year })
+	return stats
+}
+
+func newYearStats(year int, books []*Book, maxRating int) *YearStats {
+	stats := &YearStats{
+		Year:          year,
+		BooksRead:     len(books),
+		RatingMap:     RatingMap(books, maxRating),
+		RepeatAuthors: []string{},
+	}
+
+	ratingSum, ratedCount := 0, 0
+	authorCounts := map[string]int{}
+	for _, book := range books {
+		stats.PagesRead += book.Pages
+		if book.Rating > 0 {
+			ratingSum += book.Rating
+			ratedCount++
+		}
+		for _, author := range book.Authors {
+			authorCounts[author]++
+		}
+	}
+	if ratedCount > 0 {
+		stats.AverageRating = float64(ratingSum) / float64(ratedCount)
+	}
+	for author, count := range authorCounts {
+		if count > 1 {
+			stats.RepeatAuthors = append(stats.RepeatAuthors, author)
+		}
+	}
+	sort.Strings(stats.RepeatAuthors)
+	return stats
+}
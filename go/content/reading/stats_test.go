@@ -0,0 +1,40 @@
+package reading
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/s12chung/gostatic/go/test"
+)
+
+func TestYearlyStats(t *testing.T) {
+	books := []*Book{
+		{Authors: []string{"B", "A"}, Rating: 4, Pages: 100, Shelf: ReadShelf, ReadAt: date(2018, 1, 1)},
+		{Authors: []string{"A"}, Rating: 1, Pages: 200, Shelf: ReadShelf, ReadAt: date(2018, 5, 1)},
+		{Authors: []string{"B"}, Pages: 50, Shelf: ReadShelf, AddedAt: date(2018, 6, 1)},
+		{Authors: []string{"A"}, Rating: 5, Pages: 300, Shelf: ReadShelf, ReadAt: date(2016, 1, 1)},
+		{Authors: []string{"A"}, Rating: 5, Pages: 300, Shelf: ToReadShelf, AddedAt: date(2016, 1, 1)},
+		{Authors: []string{"C"}, Shelf: ReadShelf, ReadAt: date(2017, 1, 1)},
+	}
+
+	got := YearlyStats(books, 5)
+	exp := []*YearStats{
+		{2018, 3, 350, 2.5, map[int]int{1: 1, 2: 0, 3: 0, 4: 1, 5: 0}, []string{"A", "B"}},
+		{2017, 1, 0, 0, map[int]int{1: 0, 2: 0, 3: 0, 4: 0, 5: 0}, []string{}},
+		{2016, 1, 300, 5, map[int]int{1: 0, 2: 0, 3: 0, 4: 0, 5: 1}, []string{}},
+	}
+	if !cmp.Equal(got, exp) {
+		t.Error(test.NewContext().DiffString("Result", got, exp, cmp.Diff(got, exp)))
+	}
+
+	got = YearlyStats(books[:2], 3)
+	exp = []*YearStats{{2018, 2, 300, 2.5, map[int]int{1: 1, 2: 0, 3: 0}, []string{"A"}}}
+	if !cmp.Equal(got, exp) {
+		t.Error(test.NewContext().DiffString("Result with a 3 star scale", got, exp, cmp.Diff(got, exp)))
+	}
+
+	if len(YearlyStats(nil, 5)) != 0 {
+		t.Error("YearlyStats(nil) is not empty")
+	}
+}
@@ -0,0 +1,43 @@
+package reading
+
+const storyGraphCSVDateFormat = "2006/01/02"
+
+type storyGraphCSVSource struct {
+	filePath string
+}
+
+// Books parses the StoryGraph export, see https://app.thestorygraph.com/user-export
+func (source *storyGraphCSVSource) Books() ([]*Book, error) {
+	return csvBooks(source.filePath, storyGraphCSVBook)
+}
+
+func storyGraphCSVBook(header csvHeader, record []string) (*Book, error) {
+	book := &Book{
+		Title:   header.get(record, "Title"),
+		Authors: splitList(header.get(record, "Authors")),
+		Shelf:   header.get(record, "Read Status"),
+		Shelves: splitList(header.get(record, "Tags")),
+		Review:  header.get(record, "Review"),
+	}
+	isbn := header.get(record, "ISBN/UID")
+	if len(isbn) == 13 {
+		book.ISBN13 = isbn
+	} else {
+		book.ISBN = isbn
+	}
+
+	var err error
+	book.Rating, err = parseRating(header.get(record, "Star Rating"))
+	if err != nil {
+		return nil, err
+	}
+	book.AddedAt, err = parseDate(storyGraphCSVDateFormat, header.get(record, "Date Added"))
+	if err != nil {
+		return nil, err
+	}
+	book.ReadAt, err = parseDate(storyGraphCSVDateFormat, header.get(record, "Last Date Read"))
+	if err != nil {
+		return nil, err
+	}
+	return book, nil
+}
@@ -0,0 +1,41 @@
+package reading
+
+import (
+	"path"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/s12chung/gostatic/go/test"
+)
+
+func TestStoryGraphCSVSource_Books(t *testing.T) {
+	books, err := (&storyGraphCSVSource{path.Join(test.FixturePath, "storygraph_export.csv")}).Books()
+	if err != nil {
+		t.Error(err)
+	}
+
+	exp := []*Book{
+		{
+			Title:   "The Organization Man",
+			Authors: []string{"William H. Whyte", "Carlota Pérez"},
+			ISBN13:  "9780812218190",
+			Rating:  5,
+			Shelf:   ReadShelf,
+			Shelves: []string{"history", "sociology"},
+			AddedAt: date(2018, 6, 1),
+			ReadAt:  date(2018, 7, 15),
+			Review:  "Great look at corporate life.",
+		},
+		{
+			Title:   "Sapiens",
+			Authors: []string{"Yuval Noah Harari"},
+			ISBN:    "0062316095",
+			Shelf:   CurrentlyReadingShelf,
+			AddedAt: date(2018, 9, 1),
+		},
+	}
+	if !cmp.Equal(books, exp) {
+		t.Error(test.NewContext().DiffString("Result", books, exp, cmp.Diff(books, exp)))
+	}
+}
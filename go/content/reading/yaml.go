@@ -0,0 +1,31 @@
+package reading
+
+import (
+	"io/ioutil"
+
+	"gopkg.in/yaml.v2"
+)
+
+type yamlSource struct {
+	filePath string
+}
+
+// Books parses a hand maintained list of books, books without a shelf are read
+func (source *yamlSource) Books() ([]*Book, error) {
+	bytes, err := ioutil.ReadFile(source.filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var books []*Book
+	err = yaml.Unmarshal(bytes, &books)
+	if err != nil {
+		return nil, err
+	}
+	for _, book := range books {
+		if book.Shelf == "" {
+			book.Shelf = ReadShelf
+		}
+	}
+	return books, nil
+}
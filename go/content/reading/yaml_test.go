@@ -0,0 +1,40 @@
+package reading
+
+import (
+	"path"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/s12chung/gostatic/go/test"
+)
+
+func TestYAMLSource_Books(t *testing.T) {
+	books, err := (&yamlSource{path.Join(test.FixturePath, "books.yml")}).Books()
+	if err != nil {
+		t.Error(err)
+	}
+
+	exp := []*Book{
+		{
+			Title:   "The Organization Man",
+			Authors: []string{"William H. Whyte"},
+			ISBN13:  "9780812218190",
+			Rating:  4,
+			Shelf:   ReadShelf,
+			Shelves: []string{"history"},
+			AddedAt: date(2018, 6, 1),
+			ReadAt:  date(2018, 7, 15),
+			Review:  "Great look at corporate life.",
+		},
+		{
+			Title:   "Sapiens",
+			Authors: []string{"Yuval Noah Harari"},
+			Shelf:   CurrentlyReadingShelf,
+			AddedAt: date(2018, 9, 1),
+		},
+	}
+	if !cmp.Equal(books, exp) {
+		t.Error(test.NewContext().DiffString("Result", books, exp, cmp.Diff(books, exp)))
+	}
+}
@@ -1,75 +1,176 @@
 package routes
 
 import (
+	"fmt"
+	"net/url"
+	"path"
 	"sort"
 	"time"
 
+	"github.com/sirupsen/logrus"
+
 	"github.com/s12chung/go_homepage/go/content/atom"
 	"github.com/s12chung/go_homepage/go/content/models"
+	"github.com/s12chung/go_homepage/go/content/reading"
+	"github.com/s12chung/go_homepage/go/content/webmention"
 
 	"github.com/s12chung/gostatic/go/app"
 	"github.com/s12chung/gostatic/go/lib/router"
-
-	"github.com/s12chung/gostatic-packages/goodreads"
 )
 
+// AllRoutes has the handlers of every route group, the groups set the routes
 type AllRoutes struct {
-	h Helper
+	h     Helper
+	cache *buildCache
 }
 
 func NewAllRoutes(h Helper) *AllRoutes {
-	return &AllRoutes{h}
+	return &AllRoutes{h, newBuildCache()}
 }
 
-func (routes *AllRoutes) SetRoutes(r router.Router, tracker *app.Tracker) error {
-	r.GetRootHTML(routes.getPosts)
-	tracker.AddDependentURL(router.RootURL)
-	r.Get("/posts.atom", routes.getPostsAtom)
-	tracker.AddDependentURL("/posts.atom")
+type readingData struct {
+	Title        string
+	Books        []*reading.Book
+	RatingMap    map[int]int
+	EarliestYear int
+	Legend       []*legendRating
+	Sections     []*readingSection
+	Shelves      []*readingShelf
+	// BookPosts are the posts that discuss a book, by book slug
+	BookPosts map[string][]*models.Post
+}
 
-	r.GetHTML("/reading", routes.getReading)
-	r.GetHTML("/about", routes.getAbout)
-	r.Get("/robots.txt", routes.getRobotsTxt)
-	r.Get("/404.html", routes.get404)
+// legendRating describes a rating of the legend
+type legendRating struct {
+	Rating     int
+	Definition string
+}
 
-	allPostFilenames, err := models.AllPostFilenames()
-	if err != nil {
-		return err
+func ratingLegend(legend []string) []*legendRating {
+	ratings := reading.Ratings(len(legend))
+	legendRatings := make([]*legendRating, len(legend))
+	for i, definition := range legend {
+		legendRatings[i] = &legendRating{ratings[i], definition}
 	}
-	for _, filename := range allPostFilenames {
-		r.GetHTML(filename, routes.getPostF(filename))
-	}
-	return nil
+	return legendRatings
 }
 
-func (routes *AllRoutes) getAbout(ctx router.Context) error {
-	return routes.h.RespondHTML(ctx, ctx.URL(), layoutData{"About", nil})
+// readingShelf links to a shelf page
+type readingShelf struct {
+	Name string
+	URL  string
 }
 
-type readingData struct {
-	Books        []*goodreads.Book
-	RatingMap    map[int]int
-	EarliestYear int
+// readingSection lists the books of an exclusive shelf other than reading.ReadShelf
+type readingSection struct {
+	Title string
+	Books []*reading.Book
+}
+
+var readingSectionTitles = map[string]string{
+	reading.CurrentlyReadingShelf: "Currently Reading",
+	reading.ToReadShelf:           "To Read",
+}
+
+func sortBooks(books []*reading.Book) []*reading.Book {
+	sort.Slice(books, func(i, j int) bool { return books[i].SortedDate().After(books[j].SortedDate()) })
+	return books
+}
+
+// sortedReadBooks are the read books, newest first
+func (routes *AllRoutes) sortedReadBooks(log logrus.FieldLogger) ([]*reading.Book, error) {
+	books, err := routes.books(log)
+	if err != nil {
+		return nil, err
+	}
+	return sortBooks(reading.ReadBooks(books)), nil
 }
 
 func (routes *AllRoutes) getReading(ctx router.Context) error {
-	books, err := goodreads.NewClient(routes.h.GoodreadsSettings(), ctx.Log()).GetBooks()
+	return routes.respondReading(ctx, "Reading", "")
+}
+
+// respondReading responds with the reading page, only showing the books on the shelf if shelf is not ""
+func (routes *AllRoutes) respondReading(ctx router.Context, title, shelf string) error {
+	allBooks, err := routes.books(ctx.Log())
 	if err != nil {
 		return err
 	}
-	sort.Slice(books, func(i, j int) bool { return books[i].SortedDate().After(books[j].SortedDate()) })
+	if shelf != "" {
+		allBooks = reading.BooksOnShelf(allBooks, shelf)
+	}
+	books := sortBooks(reading.ReadBooks(allBooks))
 
 	earliestYear := time.Now().Year()
 	if len(books) >= 1 {
 		earliestYear = books[len(books)-1].SortedDate().Year()
 	}
 
+	settings := routes.h.ReadingSettings()
+	var sections []*readingSection
+	for _, extraShelf := range settings.ExtraShelves {
+		sectionTitle, exists := readingSectionTitles[extraShelf]
+		if !exists {
+			return fmt.Errorf("unknown extra shelf: %v", extraShelf)
+		}
+		sectionBooks := reading.BooksOnShelf(allBooks, extraShelf)
+		if len(sectionBooks) != 0 {
+			sections = append(sections, &readingSection{sectionTitle, sortBooks(sectionBooks)})
+		}
+	}
+
+	var shelves []*readingShelf
+	if settings.ShelfPages && shelf == "" {
+		for _, name := range reading.Shelves(books) {
+			shelves = append(shelves, &readingShelf{name, readingShelfURL(name)})
+		}
+	}
+
+	postsByBook := map[string][]*models.Post{}
+	if routes.h.GroupsSettings().Posts {
+		postsByBook, err = bookPosts(allBooks)
+		if err != nil {
+			return err
+		}
+	}
+
 	data := readingData{
+		title,
 		books,
-		goodreads.RatingMap(books),
+		reading.RatingMap(books, settings.MaxRating()),
 		earliestYear,
+		ratingLegend(settings.Legend),
+		sections,
+		shelves,
+		postsByBook,
+	}
+	return routes.h.RespondHTML(ctx, "reading", routes.newLayoutData(ctx, title, data))
+}
+
+// bookPosts returns the posts that discuss each book, by book slug
+func bookPosts(books []*reading.Book) (map[string][]*models.Post, error) {
+	posts, err := sortedPosts()
+	if err != nil {
+		return nil, err
+	}
+
+	postMap := map[string][]*models.Post{}
+	for _, post := range posts {
+		for _, ref := range post.Books {
+			book := reading.FindBook(books, ref)
+			if book != nil {
+				postMap[book.Slug()] = append(postMap[book.Slug()], post)
+			}
+		}
 	}
-	return routes.h.RespondHTML(ctx, ctx.URL(), layoutData{"Reading", data})
+	return postMap, nil
+}
+
+type postData struct {
+	*models.Post
+	ReferencedBooks []*reading.Book
+	Comments        []*models.Comment
+	Mentions        *webmention.PostMentions
 }
 
 func (routes *AllRoutes) getPostF(filename string) func(ctx router.Context) error {
@@ -78,12 +179,84 @@ func (routes *AllRoutes) getPostF(filename string) func(ctx router.Context) erro
 		if err != nil {
 			return err
 		}
-		return routes.h.RespondHTML(ctx, "post", layoutData{post.Title, post})
+		books, err := routes.referencedBooks(ctx, post)
+		if err != nil {
+			return err
+		}
+		comments, err := models.Comments(post)
+		if err != nil {
+			return err
+		}
+		mentions, err := routes.receivedMentions()
+		if err != nil {
+			return err
+		}
+
+		data := routes.postLayoutData(ctx, post)
+		data.Alternates, err = routes.postAlternates(post)
+		if err != nil {
+			return err
+		}
+		data.ContentData = postData{post, books, comments, webmention.MentionsOf(mentions, post.URL())}
+		return routes.h.RespondHTML(ctx, "post", data)
 	}
 }
 
+// referencedBooks returns the books in the post's front matter, only getting the book list when there are books
+// and the reading group is enabled
+func (routes *AllRoutes) referencedBooks(ctx router.Context, post *models.Post) ([]*reading.Book, error) {
+	if len(post.Books) == 0 || !routes.h.GroupsSettings().Reading {
+		return nil, nil
+	}
+	log := ctx.Log()
+	books, err := routes.books(log)
+	if err != nil {
+		return nil, err
+	}
+
+	var referencedBooks []*reading.Book
+	for _, ref := range post.Books {
+		book := reading.FindBook(books, ref)
+		if book == nil {
+			log.Warnf("book %v in post %v is not in the book list", ref, post.ID())
+			continue
+		}
+		referencedBooks = append(referencedBooks, book)
+	}
+	return referencedBooks, nil
+}
+
+func (routes *AllRoutes) postLayoutData(ctx router.Context, post *models.Post) layoutData {
+	data := routes.newLayoutData(ctx, post.Title, post)
+	data.Type = articleType
+	if post.Lang != "" {
+		data.Lang = post.Lang
+		data.FeedURL = routes.feedURL(langFeedURL(post.Lang))
+	}
+	if post.Description != "" {
+		data.Description = post.Description
+	}
+	if post.Image != "" {
+		data.ImageURL = routes.h.SiteSettings().AbsoluteURL(routes.contentImageURL(post.Image))
+		data.TwitterCard = largeSummaryCard
+	}
+	data.StructuredData = []interface{}{routes.blogPostingSchema(post, data)}
+	return data
+}
+
+// contentImageURL uses the same manifest keys as replaceResponsiveAttrs "content"
+func (routes *AllRoutes) contentImageURL(image string) string {
+	u, err := url.Parse(image)
+	if err == nil && u.IsAbs() {
+		return image
+	}
+	return routes.h.ManifestURL(path.Join("content", image))
+}
+
 type postsData struct {
 	Posts []*models.Post
+	// CommentCounts are by post ID
+	CommentCounts map[string]int
 }
 
 func (routes *AllRoutes) getPosts(ctx router.Context) error {
@@ -92,21 +265,103 @@ func (routes *AllRoutes) getPosts(ctx router.Context) error {
 		return err
 	}
 
+	commentCounts := map[string]int{}
+	for _, post := range posts {
+		commentCounts[post.ID()], err = models.CommentCount(post)
+		if err != nil {
+			return err
+		}
+	}
+
 	data := postsData{
 		posts,
+		commentCounts,
+	}
+	layoutD := routes.newLayoutData(ctx, "", data)
+	layoutD.StructuredData = []interface{}{routes.webSiteSchema()}
+	return routes.h.RespondHTML(ctx, "posts", layoutD)
+}
+
+// setPostsArchiveAtomRoutes sets the archive feeds of the posts in lang, lang is "" for the site's language
+func (routes *AllRoutes) setPostsArchiveAtomRoutes(r router.Router, tracker *app.Tracker, lang string) error {
+	posts, err := sortedLangPosts(lang)
+	if err != nil {
+		return err
 	}
-	return routes.h.RespondHTML(ctx, "posts", layoutData{"", data})
+	archiveCount := atom.ArchiveCount(len(posts), routes.h.SiteSettings().FeedEntryLimit)
+	for number := 1; number <= archiveCount; number++ {
+		archiveURL := postsFeedPrefix(lang) + atom.ArchiveURL(number)
+		r.Get(archiveURL, routes.getPostsArchiveAtomF(lang, number))
+		tracker.AddDependentURL(archiveURL)
+	}
+	return nil
 }
 
 func (routes *AllRoutes) getPostsAtom(ctx router.Context) error {
-	posts, err := sortedPosts()
+	return routes.respondCurrentPostsAtom(ctx, "")
+}
+
+// respondCurrentPostsAtom responds with the current feed of the posts in lang, lang is "" for the site's language
+func (routes *AllRoutes) respondCurrentPostsAtom(ctx router.Context, lang string) error {
+	posts, err := sortedLangPosts(lang)
+	if err != nil {
+		return err
+	}
+
+	var history *atom.History
+	settings := routes.h.SiteSettings()
+	archiveCount := atom.ArchiveCount(len(posts), settings.FeedEntryLimit)
+	if archiveCount > 0 {
+		history = &atom.History{PrevArchive: settings.AbsoluteURL(postsFeedPrefix(lang) + atom.ArchiveURL(archiveCount))}
+	}
+	return routes.respondPostsAtom(ctx, posts, history)
+}
+
+func (routes *AllRoutes) getPostsArchiveAtomF(lang string, number int) func(ctx router.Context) error {
+	return func(ctx router.Context) error {
+		posts, err := sortedLangPosts(lang)
+		if err != nil {
+			return err
+		}
+
+		settings := routes.h.SiteSettings()
+		archiveCount := atom.ArchiveCount(len(posts), settings.FeedEntryLimit)
+		if number > archiveCount {
+			return fmt.Errorf("archive %v does not exist, there are %v archives", number, archiveCount)
+		}
+
+		prefix := postsFeedPrefix(lang)
+		history := &atom.History{
+			Archive: true,
+			Current: settings.AbsoluteURL(prefix + atom.CurrentURL),
+		}
+		if number > 1 {
+			history.PrevArchive = settings.AbsoluteURL(prefix + atom.ArchiveURL(number-1))
+		}
+		if number < archiveCount {
+			history.NextArchive = settings.AbsoluteURL(prefix + atom.ArchiveURL(number+1))
+		}
+		return routes.respondPostsAtom(ctx, atom.ArchivePosts(posts, number, settings.FeedEntryLimit), history)
+	}
+}
+
+func (routes *AllRoutes) respondPostsAtom(ctx router.Context, posts []*models.Post, history *atom.History) error {
+	settings := routes.h.SiteSettings()
+	logoURL := settings.AbsoluteURL(routes.h.ManifestURL("images/logo.png"))
+	htmlEntries := atom.PostsToHTMLEntries(posts, settings, routes.contentImageURL)
+	return routes.h.RespondAtom(ctx, "posts", logoURL, htmlEntries, history)
+}
+
+func (routes *AllRoutes) getReadingAtom(ctx router.Context) error {
+	books, err := routes.sortedReadBooks(ctx.Log())
 	if err != nil {
 		return err
 	}
 
-	logoURL := routes.h.ManifestURL("images/logo.png")
-	htmlEntries := atom.PostsToHTMLEntries(posts)
-	return routes.h.RespondAtom(ctx, "posts", logoURL, htmlEntries)
+	settings := routes.h.SiteSettings()
+	logoURL := settings.AbsoluteURL(routes.h.ManifestURL("images/logo.png"))
+	htmlEntries := atom.BooksToHTMLEntries(books, settings, routes.h.ReadingSettings().MaxRating())
+	return routes.h.RespondAtom(ctx, "reading", logoURL, htmlEntries, nil)
 }
 
 func (routes *AllRoutes) getRobotsTxt(ctx router.Context) error {
@@ -116,15 +371,52 @@ func (routes *AllRoutes) getRobotsTxt(ctx router.Context) error {
 	return nil
 }
 
-func (routes *AllRoutes) get404(ctx router.Context) error {
-	return routes.h.RespondHTML(ctx, ctx.URL(), layoutData{"404", nil})
+func (routes *AllRoutes) newLayoutData(ctx router.Context, title string, contentData interface{}) layoutData {
+	currentURL := ctx.URL()
+	nav, err := routes.navItems(currentURL)
+	if err != nil {
+		ctx.Log().Errorf("could not get the navigation - %v", err)
+	}
+
+	settings := routes.h.SiteSettings()
+	return layoutData{
+		Title:         title,
+		Description:   settings.Description,
+		URL:           settings.AbsoluteURL(currentURL),
+		Type:          websiteType,
+		ImageURL:      settings.AbsoluteURL(routes.h.ManifestURL(settings.Image)),
+		TwitterCard:   summaryCard,
+		TwitterHandle: settings.TwitterHandle,
+		Lang:          settings.Language,
+		FeedURL:       routes.feedURL(atom.CurrentURL),
+		Nav:           nav,
+		ContentData:   contentData,
+	}
+}
+
+// feedURL is the absolute URL of the feed, empty when the feeds group is disabled
+func (routes *AllRoutes) feedURL(urlPath string) string {
+	if !routes.h.GroupsSettings().Feeds {
+		return ""
+	}
+	return routes.h.SiteSettings().AbsoluteURL(urlPath)
 }
 
+// sortedPosts are the posts in the site's language, newest first
 func sortedPosts() ([]*models.Post, error) {
-	posts, err := models.Posts()
+	return sortedLangPosts("")
+}
+
+// sortedLangPosts are the posts in lang, newest first, lang is "" for the site's language
+func sortedLangPosts(lang string) ([]*models.Post, error) {
+	posts, err := models.AllPosts(func(post *models.Post) bool { return !post.IsDraft && post.Lang == lang })
 	if err != nil {
 		return nil, err
 	}
+	return sortPosts(posts), nil
+}
+
+func sortPosts(posts []*models.Post) []*models.Post {
 	sort.Slice(posts, func(i, j int) bool { return posts[i].PublishedAt.After(posts[j].PublishedAt) })
-	return posts, nil
+	return posts
 }
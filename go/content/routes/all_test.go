@@ -19,7 +19,13 @@ import (
 	"github.com/s12chung/gostatic-packages/atom"
 	"github.com/s12chung/gostatic-packages/goodreads"
 
+	postsatom "github.com/s12chung/go_homepage/go/content/atom"
+	"github.com/s12chung/go_homepage/go/content/groups"
 	"github.com/s12chung/go_homepage/go/content/models"
+	"github.com/s12chung/go_homepage/go/content/nav"
+	"github.com/s12chung/go_homepage/go/content/reading"
+	"github.com/s12chung/go_homepage/go/content/site"
+	"github.com/s12chung/go_homepage/go/content/webmention"
 	"github.com/s12chung/go_homepage/go/test/mocks"
 )
 
@@ -52,6 +58,61 @@ func goodreadsSettings(t *testing.T, apiURL string) (*goodreads.Settings, func()
 	return settings, clean
 }
 
+func testSiteSettings() *site.Settings {
+	settings := site.DefaultSettings()
+	settings.URL = "https://test.com"
+	settings.Description = "Site Description"
+	settings.TwitterHandle = "@test"
+	return settings
+}
+
+const testLogoURL = "/assets/logo.png"
+
+func testWebmentionSettings() *webmention.Settings {
+	settings := webmention.DefaultSettings()
+	settings.ReceivedPath = path.Join("../webmention", test.FixturePath, "webmentions.json")
+	return settings
+}
+
+func testAtomSettings() *atom.Settings {
+	settings := atom.DefaultSettings()
+	settings.AuthorName = "Test Author"
+	return settings
+}
+
+func expectLayoutData(helper *mocks.MockHelper) {
+	helper.EXPECT().SiteSettings().Return(testSiteSettings()).AnyTimes()
+	helper.EXPECT().GroupsSettings().Return(groups.DefaultSettings()).AnyTimes()
+	helper.EXPECT().NavSettings().Return(nav.DefaultSettings()).AnyTimes()
+	helper.EXPECT().AtomSettings().Return(testAtomSettings()).AnyTimes()
+	helper.EXPECT().ManifestURL("images/logo.png").Return(testLogoURL)
+}
+
+func testLayoutData(title, url string, contentData interface{}) layoutData {
+	return layoutData{
+		Title:         title,
+		Description:   "Site Description",
+		URL:           "https://test.com" + url,
+		Type:          websiteType,
+		ImageURL:      "https://test.com" + testLogoURL,
+		TwitterCard:   summaryCard,
+		TwitterHandle: "@test",
+		Lang:          "en",
+		FeedURL:       "https://test.com/posts.atom",
+		Nav:           testNav(url),
+		ContentData:   contentData,
+	}
+}
+
+// testNav is the default nav settings with the nav pages of the models fixtures
+func testNav(currentURL string) []*navItem {
+	return []*navItem{
+		{"Reading", "/reading", false, currentURL == "/reading"},
+		{"Now", "/now", false, currentURL == "/now"},
+		{"About", "/about", false, currentURL == "/about"},
+	}
+}
+
 func TestMain(m *testing.M) {
 	modelsConfig()
 	retCode := m.Run()
@@ -66,28 +127,19 @@ func testRoute(t *testing.T, callback func(helper *mocks.MockHelper, ctx *mocks.
 	callback(helper, ctx)
 }
 
-func TestAllRoutes_getAbout(t *testing.T) {
-	testRoute(t, func(helper *mocks.MockHelper, ctx *mocks.MockContext) {
-		ctx.EXPECT().URL().Return("/about")
-		helper.EXPECT().RespondHTML(ctx, "/about", layoutData{"About", nil})
-
-		err := NewAllRoutes(helper).getAbout(ctx)
-		if err != nil {
-			t.Error(err)
-		}
-	})
-}
-
 type readingTestCase struct {
 	emptyResponse bool
 	years         []int
 	ratingMap     map[int]int
+	bookPostIDs   map[string][]string
 }
 
 func TestAllRoutes_getReading(t *testing.T) {
 	testCases := []readingTestCase{
-		{true, []int{}, map[int]int{1: 0, 2: 0, 3: 0, 4: 0, 5: 0}},
-		{false, []int{2000, 2010, 2018}, map[int]int{1: 0, 2: 1, 3: 0, 4: 2, 5: 0}},
+		{true, []int{}, map[int]int{1: 0, 2: 0, 3: 0, 4: 0, 5: 0}, map[string][]string{}},
+		{false, []int{2000, 2010, 2018}, map[int]int{1: 0, 2: 1, 3: 0, 4: 2, 5: 0}, map[string][]string{
+			"the-organization-man-the-book-that-defined-a-generation": {"post2"},
+		}},
 	}
 
 	for testCaseIndex, tc := range testCases {
@@ -115,8 +167,10 @@ func TestAllRoutes_getReading(t *testing.T) {
 			defer clean()
 
 			ctx.EXPECT().URL().Return("/reading")
+			expectLayoutData(helper)
+			helper.EXPECT().ReadingSettings().Return(reading.DefaultSettings()).Times(2)
 			helper.EXPECT().GoodreadsSettings().Return(settings)
-			helper.EXPECT().RespondHTML(ctx, "/reading", gomock.Any()).Do(testReadingResponseF(t, context, tc))
+			helper.EXPECT().RespondHTML(ctx, "reading", gomock.Any()).Do(testReadingResponseF(t, context, tc))
 
 			err := NewAllRoutes(helper).getReading(ctx)
 			if err != nil {
@@ -126,6 +180,35 @@ func TestAllRoutes_getReading(t *testing.T) {
 	}
 }
 
+func TestAllRoutes_getReading_GoodreadsCSV(t *testing.T) {
+	testRoute(t, func(helper *mocks.MockHelper, ctx *mocks.MockContext) {
+		context := test.NewContext()
+
+		settings := reading.DefaultSettings()
+		settings.Source = reading.GoodreadsCSVSource
+		settings.GoodreadsCSVPath = path.Join("../reading", test.FixturePath, "goodreads_library_export.csv")
+
+		log, _ := logTest.NewNullLogger()
+		ctx.EXPECT().Log().Return(log)
+		ctx.EXPECT().URL().Return("/reading")
+		expectLayoutData(helper)
+		helper.EXPECT().ReadingSettings().Return(settings).Times(2)
+		helper.EXPECT().GoodreadsSettings().Return(nil)
+		tc := readingTestCase{
+			false,
+			[]int{2010, 2018},
+			map[int]int{1: 0, 2: 1, 3: 0, 4: 1, 5: 0},
+			map[string][]string{"the-organization-man-the-book-that-defined-a-generation": {"post2"}},
+		}
+		helper.EXPECT().RespondHTML(ctx, "reading", gomock.Any()).Do(testReadingResponseF(t, context, tc))
+
+		err := NewAllRoutes(helper).getReading(ctx)
+		if err != nil {
+			t.Error(context.String(err))
+		}
+	})
+}
+
 func testReadingResponseF(t *testing.T, context *test.Context, tc readingTestCase) func(ctx router.Context, templateName string, data interface{}) {
 	return func(ctx router.Context, templateName string, data interface{}) {
 		layoutD, ok := data.(layoutData)
@@ -152,15 +235,31 @@ func testReadingResponseF(t *testing.T, context *test.Context, tc readingTestCas
 			t.Error(context.GotExpString("years", years, tc.years))
 		}
 
+		bookPostIDs := map[string][]string{}
+		for slug, posts := range d.BookPosts {
+			for _, post := range posts {
+				bookPostIDs[slug] = append(bookPostIDs[slug], post.ID())
+			}
+		}
+		if !cmp.Equal(bookPostIDs, tc.bookPostIDs) {
+			t.Error(context.GotExpString("bookPostIDs", bookPostIDs, tc.bookPostIDs))
+		}
+
 		d.Books = nil
+		d.BookPosts = nil
 		earliestYear := time.Now().Year()
 		if len(tc.years) != 0 {
 			earliestYear = tc.years[0]
 		}
 		exp := readingData{
+			"Reading",
 			nil,
 			tc.ratingMap,
 			earliestYear,
+			ratingLegend(reading.DefaultSettings().Legend),
+			nil,
+			nil,
+			nil,
 		}
 		if !cmp.Equal(d, exp) {
 			t.Error(context.GotExpString("d", d, exp))
@@ -172,10 +271,12 @@ func TestAllRoutes_getPost(t *testing.T) {
 	testCases := []struct {
 		postFilename string
 		exists       bool
+		commentCount int
+		mentionCount int
 	}{
-		{"draft1", true},
-		{"post1", true},
-		{"does not exist", false},
+		{"draft1", true, 0, 0},
+		{"post1", true, 2, 2},
+		{"does not exist", false, 0, 0},
 	}
 
 	for testCaseIndex, tc := range testCases {
@@ -186,24 +287,40 @@ func TestAllRoutes_getPost(t *testing.T) {
 			})
 
 			if tc.exists {
+				helper.EXPECT().WebmentionSettings().Return(testWebmentionSettings())
+				ctx.EXPECT().URL().Return("/" + tc.postFilename)
+				expectLayoutData(helper)
 				helper.EXPECT().RespondHTML(ctx, "post", gomock.Any()).Do(func(ctx router.Context, templateName string, data interface{}) {
 					layoutD, ok := data.(layoutData)
 					if !ok {
 						t.Error(context.Stringf("could not convert to: %v", layoutData{}))
 						return
 					}
-					post, ok := layoutD.ContentData.(*models.Post)
+					d, ok := layoutD.ContentData.(postData)
 					if !ok {
-						t.Error(context.Stringf("could not convert to: %v", models.Post{}))
+						t.Error(context.Stringf("could not convert to: %v", postData{}))
 						return
 					}
+					post := d.Post
 					if layoutD.Title != post.Title {
 						t.Error(context.GotExpString("layoutD.Title", layoutD.Title, post.Title))
 					}
+					if layoutD.Description != post.Description {
+						t.Error(context.GotExpString("layoutD.Description", layoutD.Description, post.Description))
+					}
+					if layoutD.Type != articleType {
+						t.Error(context.GotExpString("layoutD.Type", layoutD.Type, articleType))
+					}
 
 					if post.ID() != tc.postFilename {
 						t.Error(context.GotExpString("Wrong Post", post.ID(), tc.postFilename))
 					}
+					if len(d.Comments) != tc.commentCount {
+						t.Error(context.GotExpString("len(d.Comments)", len(d.Comments), tc.commentCount))
+					}
+					if d.Mentions.Count() != tc.mentionCount {
+						t.Error(context.GotExpString("d.Mentions.Count()", d.Mentions.Count(), tc.mentionCount))
+					}
 				})
 			}
 
@@ -221,13 +338,51 @@ func TestAllRoutes_getPost(t *testing.T) {
 	}
 }
 
+func TestAllRoutes_postLayoutData(t *testing.T) {
+	testCases := []struct {
+		image       string
+		expImageURL string
+		expCard     string
+	}{
+		{"", "https://test.com" + testLogoURL, summaryCard},
+		{"images/test.png", "https://test.com/assets/content/images/test.png", largeSummaryCard},
+		{"https://other.com/image.png", "https://other.com/image.png", largeSummaryCard},
+	}
+
+	for testCaseIndex, tc := range testCases {
+		testRoute(t, func(helper *mocks.MockHelper, ctx *mocks.MockContext) {
+			context := test.NewContext().SetFields(test.ContextFields{
+				"index": testCaseIndex,
+				"image": tc.image,
+			})
+
+			ctx.EXPECT().URL().Return("/post")
+			expectLayoutData(helper)
+			helper.EXPECT().ManifestURL("content/images/test.png").Return("/assets/content/images/test.png").AnyTimes()
+
+			post := &models.Post{Title: "Post", Description: "Post Description", Image: tc.image}
+			got := NewAllRoutes(helper).postLayoutData(ctx, post)
+			exp := testLayoutData("Post", "/post", post)
+			exp.Description = post.Description
+			exp.Type = articleType
+			exp.ImageURL = tc.expImageURL
+			exp.TwitterCard = tc.expCard
+			exp.StructuredData = []interface{}{NewAllRoutes(helper).blogPostingSchema(post, exp)}
+			if !cmp.Equal(got, exp) {
+				t.Error(context.DiffString("Result", got, exp, cmp.Diff(got, exp)))
+			}
+		})
+	}
+}
+
 func TestAllRoutes_getPosts(t *testing.T) {
 	testCases := []struct {
-		postDirEmpty bool
-		expected     []string
+		postDirEmpty     bool
+		expected         []string
+		expCommentCounts map[string]int
 	}{
-		{true, []string{}},
-		{false, []string{"post1", "post2"}},
+		{true, []string{}, map[string]int{}},
+		{false, []string{"post1", "post2"}, map[string]int{"post1": 3, "post2": 0}},
 	}
 
 	for testCaseIndex, tc := range testCases {
@@ -242,6 +397,8 @@ func TestAllRoutes_getPosts(t *testing.T) {
 				setPostDirEmpty()
 			}
 
+			ctx.EXPECT().URL().Return("/")
+			expectLayoutData(helper)
 			helper.EXPECT().RespondHTML(ctx, "posts", gomock.Any()).Do(func(ctx router.Context, templateName string, data interface{}) {
 				layoutD, ok := data.(layoutData)
 				if !ok {
@@ -265,6 +422,9 @@ func TestAllRoutes_getPosts(t *testing.T) {
 				if !cmp.Equal(ids, tc.expected) {
 					t.Error(context.GotExpString("ids", ids, tc.expected))
 				}
+				if !cmp.Equal(d.CommentCounts, tc.expCommentCounts) {
+					t.Error(context.GotExpString("d.CommentCounts", d.CommentCounts, tc.expCommentCounts))
+				}
 			})
 
 			err := NewAllRoutes(helper).getPosts(ctx)
@@ -278,10 +438,13 @@ func TestAllRoutes_getPosts(t *testing.T) {
 func TestAllRoutes_getPostsAtom(t *testing.T) {
 	testCases := []struct {
 		postDirEmpty bool
+		entryLimit   int
 		expected     []string
+		expHistory   *postsatom.History
 	}{
-		{true, []string{}},
-		{false, []string{"post1", "post2"}},
+		{true, 100, []string{}, nil},
+		{false, 100, []string{"https://test.com/post1", "https://test.com/post2"}, nil},
+		{false, 1, []string{"https://test.com/post2"}, &postsatom.History{PrevArchive: "https://test.com/posts-archive-2.atom"}},
 	}
 
 	for testCaseIndex, tc := range testCases {
@@ -289,6 +452,7 @@ func TestAllRoutes_getPostsAtom(t *testing.T) {
 			context := test.NewContext().SetFields(test.ContextFields{
 				"index":        testCaseIndex,
 				"postDirEmpty": tc.postDirEmpty,
+				"entryLimit":   tc.entryLimit,
 			})
 
 			modelsConfig()
@@ -296,21 +460,9 @@ func TestAllRoutes_getPostsAtom(t *testing.T) {
 				setPostDirEmpty()
 			}
 
-			expLogoURL := "test_logo.png"
-			helper.EXPECT().ManifestURL("images/logo.png").Return(expLogoURL)
-			helper.EXPECT().RespondAtom(ctx, "posts", expLogoURL, gomock.Any()).
-				Do(func(tx router.Context, feedName, logoURL string, htmlEntries []*atom.HTMLEntry) {
-
-					ids := make([]string, len(htmlEntries))
-					for i, htmlEntry := range htmlEntries {
-						ids[i] = htmlEntry.ID
-					}
-					sort.Strings(ids)
-					sort.Strings(tc.expected)
-					if !cmp.Equal(ids, tc.expected) {
-						t.Error(context.GotExpString("ids", ids, tc.expected))
-					}
-				})
+			settings := testSiteSettings()
+			settings.FeedEntryLimit = tc.entryLimit
+			expectPostsAtom(t, context, helper, ctx, settings, tc.expected, tc.expHistory)
 
 			err := NewAllRoutes(helper).getPostsAtom(ctx)
 			if err != nil {
@@ -320,6 +472,149 @@ func TestAllRoutes_getPostsAtom(t *testing.T) {
 	}
 }
 
+func TestAllRoutes_getPostsArchiveAtomF(t *testing.T) {
+	testCases := []struct {
+		lang       string
+		number     int
+		expected   []string
+		expHistory *postsatom.History
+	}{
+		{"", 1, []string{"https://test.com/post1"}, &postsatom.History{Archive: true, Current: "https://test.com/posts.atom", NextArchive: "https://test.com/posts-archive-2.atom"}},
+		{"", 2, []string{"https://test.com/post2"}, &postsatom.History{Archive: true, Current: "https://test.com/posts.atom", PrevArchive: "https://test.com/posts-archive-1.atom"}},
+		{"", 3, nil, nil},
+		{"fr", 1, []string{"https://test.com/fr/post1"}, &postsatom.History{Archive: true, Current: "https://test.com/fr/posts.atom"}},
+		{"fr", 2, nil, nil},
+	}
+
+	for testCaseIndex, tc := range testCases {
+		testRoute(t, func(helper *mocks.MockHelper, ctx *mocks.MockContext) {
+			context := test.NewContext().SetFields(test.ContextFields{
+				"index":  testCaseIndex,
+				"lang":   tc.lang,
+				"number": tc.number,
+			})
+
+			modelsConfig()
+			settings := testSiteSettings()
+			settings.FeedEntryLimit = 1
+			if tc.expHistory == nil {
+				helper.EXPECT().SiteSettings().Return(settings).AnyTimes()
+				err := NewAllRoutes(helper).getPostsArchiveAtomF(tc.lang, tc.number)(ctx)
+				if err == nil {
+					t.Error(context.String("no error for archive that does not exist"))
+				}
+				return
+			}
+			expectPostsAtom(t, context, helper, ctx, settings, tc.expected, tc.expHistory)
+
+			err := NewAllRoutes(helper).getPostsArchiveAtomF(tc.lang, tc.number)(ctx)
+			if err != nil {
+				t.Error(context.String(err))
+			}
+		})
+	}
+}
+
+func expectPostsAtom(t *testing.T, context *test.Context, helper *mocks.MockHelper, ctx *mocks.MockContext, settings *site.Settings, expected []string, expHistory *postsatom.History) {
+	expLogoURL := "https://test.com/test_logo.png"
+	helper.EXPECT().SiteSettings().Return(settings).AnyTimes()
+	helper.EXPECT().ManifestURL("images/logo.png").Return("/test_logo.png")
+	helper.EXPECT().RespondAtom(ctx, "posts", expLogoURL, gomock.Any(), expHistory).
+		Do(func(tx router.Context, feedName, logoURL string, htmlEntries []*atom.HTMLEntry, history *postsatom.History) {
+
+			ids := make([]string, len(htmlEntries))
+			for i, htmlEntry := range htmlEntries {
+				ids[i] = htmlEntry.ID
+			}
+			sort.Strings(ids)
+			sort.Strings(expected)
+			if !cmp.Equal(ids, expected) {
+				t.Error(context.GotExpString("ids", ids, expected))
+			}
+		})
+}
+
+func TestAllRoutes_getSitemap(t *testing.T) {
+	testCases := []struct {
+		postDirEmpty bool
+		exp          string
+	}{
+		{true, `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url>
+    <loc>https://test.com/</loc>
+  </url>
+  <url>
+    <loc>https://test.com/archive</loc>
+  </url>
+  <url>
+    <loc>https://test.com/reading</loc>
+  </url>
+</urlset>`},
+		{false, `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url>
+    <loc>https://test.com/</loc>
+  </url>
+  <url>
+    <loc>https://test.com/archive</loc>
+  </url>
+  <url>
+    <loc>https://test.com/reading</loc>
+  </url>
+  <url>
+    <loc>https://test.com/about</loc>
+  </url>
+  <url>
+    <loc>https://test.com/now</loc>
+  </url>
+  <url>
+    <loc>https://test.com/uses</loc>
+  </url>
+  <url>
+    <loc>https://test.com/fr/post1</loc>
+    <lastmod>2017-08-03</lastmod>
+  </url>
+  <url>
+    <loc>https://test.com/post2</loc>
+    <lastmod>2017-08-02</lastmod>
+  </url>
+  <url>
+    <loc>https://test.com/post1</loc>
+    <lastmod>2017-08-01</lastmod>
+  </url>
+</urlset>`},
+	}
+
+	for testCaseIndex, tc := range testCases {
+		testRoute(t, func(helper *mocks.MockHelper, ctx *mocks.MockContext) {
+			context := test.NewContext().SetFields(test.ContextFields{
+				"index":        testCaseIndex,
+				"postDirEmpty": tc.postDirEmpty,
+			})
+
+			modelsConfig()
+			if tc.postDirEmpty {
+				setPostDirEmpty()
+			}
+
+			helper.EXPECT().GroupsSettings().Return(groups.DefaultSettings())
+			helper.EXPECT().SiteSettings().Return(testSiteSettings())
+			ctx.EXPECT().Respond(gomock.Any()).Do(func(bytes []byte) {
+				got := string(bytes)
+				if got != tc.exp {
+					t.Error(context.GotExpString("Result", got, tc.exp))
+				}
+			})
+
+			err := NewAllRoutes(helper).getSitemap(ctx)
+			if err != nil {
+				t.Error(context.String(err))
+			}
+		})
+	}
+}
+
 func TestAllRoutes_getRobotsTxt(t *testing.T) {
 	testRoute(t, func(helper *mocks.MockHelper, ctx *mocks.MockContext) {
 		ctx.EXPECT().Respond([]byte{})
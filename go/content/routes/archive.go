@@ -0,0 +1,131 @@
+package routes
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/s12chung/go_homepage/go/content/models"
+
+	"github.com/s12chung/gostatic/go/app"
+	"github.com/s12chung/gostatic/go/lib/router"
+)
+
+const archiveURL = "/archive"
+
+type archiveMonth struct {
+	Year  int
+	Month time.Month
+	Posts []*models.Post
+}
+
+func (month *archiveMonth) URL() string {
+	return fmt.Sprintf("%v/%v/%02d", archiveURL, month.Year, int(month.Month))
+}
+
+func (month *archiveMonth) Title() string {
+	return fmt.Sprintf("%v %v", month.Month, month.Year)
+}
+
+type archiveYear struct {
+	Year   int
+	Months []*archiveMonth
+	Posts  []*models.Post
+}
+
+func (year *archiveYear) URL() string {
+	return fmt.Sprintf("%v/%v", archiveURL, year.Year)
+}
+
+func (year *archiveYear) Title() string {
+	return fmt.Sprint(year.Year)
+}
+
+// archiveYears groups the posts by year and month, posts are sorted by newest first, like the results
+func archiveYears(posts []*models.Post) []*archiveYear {
+	var years []*archiveYear
+	var year *archiveYear
+	var month *archiveMonth
+	for _, post := range posts {
+		publishedAt := post.PublishedAt
+		if year == nil || year.Year != publishedAt.Year() {
+			year = &archiveYear{Year: publishedAt.Year()}
+			years = append(years, year)
+			month = nil
+		}
+		if month == nil || month.Month != publishedAt.Month() {
+			month = &archiveMonth{Year: publishedAt.Year(), Month: publishedAt.Month()}
+			year.Months = append(year.Months, month)
+		}
+		year.Posts = append(year.Posts, post)
+		month.Posts = append(month.Posts, post)
+	}
+	return years
+}
+
+func (routes *AllRoutes) setArchivePeriodRoutes(r router.Router, tracker *app.Tracker) error {
+	posts, err := sortedPosts()
+	if err != nil {
+		return err
+	}
+
+	for _, year := range archiveYears(posts) {
+		r.GetHTML(year.URL(), routes.getArchivePeriodF(year.Year, 0))
+		tracker.AddDependentURL(year.URL())
+		for _, month := range year.Months {
+			r.GetHTML(month.URL(), routes.getArchivePeriodF(month.Year, month.Month))
+			tracker.AddDependentURL(month.URL())
+		}
+	}
+	return nil
+}
+
+type archiveData struct {
+	Years []*archiveYear
+}
+
+func (routes *AllRoutes) getArchive(ctx router.Context) error {
+	posts, err := sortedPosts()
+	if err != nil {
+		return err
+	}
+
+	data := archiveData{
+		archiveYears(posts),
+	}
+	return routes.h.RespondHTML(ctx, "archive", routes.newLayoutData(ctx, "Archive", data))
+}
+
+type archivePeriodData struct {
+	Title string
+	Posts []*models.Post
+}
+
+// getArchivePeriodF returns the route of a year's archive when month is 0
+func (routes *AllRoutes) getArchivePeriodF(year int, month time.Month) func(ctx router.Context) error {
+	return func(ctx router.Context) error {
+		posts, err := sortedPosts()
+		if err != nil {
+			return err
+		}
+
+		var data *archivePeriodData
+		for _, archiveY := range archiveYears(posts) {
+			if archiveY.Year != year {
+				continue
+			}
+			if month == 0 {
+				data = &archivePeriodData{archiveY.Title(), archiveY.Posts}
+				break
+			}
+			for _, archiveM := range archiveY.Months {
+				if archiveM.Month == month {
+					data = &archivePeriodData{archiveM.Title(), archiveM.Posts}
+				}
+			}
+		}
+		if data == nil {
+			return fmt.Errorf("no posts in archive period: %v %v", year, month)
+		}
+		return routes.h.RespondHTML(ctx, "archive_period", routes.newLayoutData(ctx, data.Title, *data))
+	}
+}
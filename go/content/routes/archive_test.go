@@ -0,0 +1,138 @@
+package routes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/s12chung/gostatic/go/lib/router"
+	"github.com/s12chung/gostatic/go/test"
+
+	"github.com/s12chung/go_homepage/go/content/models"
+	"github.com/s12chung/go_homepage/go/test/mocks"
+)
+
+func archivePost(year int, month time.Month, day int) *models.Post {
+	return &models.Post{PublishedAt: time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+func TestArchiveYears(t *testing.T) {
+	posts := []*models.Post{
+		archivePost(2018, 3, 2),
+		archivePost(2018, 3, 1),
+		archivePost(2018, 1, 5),
+		archivePost(2016, 12, 31),
+	}
+
+	got := archiveYears(posts)
+	exp := []*archiveYear{
+		{2018, []*archiveMonth{
+			{2018, 3, posts[0:2]},
+			{2018, 1, posts[2:3]},
+		}, posts[0:3]},
+		{2016, []*archiveMonth{
+			{2016, 12, posts[3:4]},
+		}, posts[3:4]},
+	}
+	if !cmp.Equal(got, exp) {
+		t.Error(test.NewContext().DiffString("Result", got, exp, cmp.Diff(got, exp)))
+	}
+
+	if len(archiveYears(nil)) != 0 {
+		t.Error("archiveYears(nil) is not empty")
+	}
+}
+
+func TestArchiveURLs(t *testing.T) {
+	year := &archiveYear{Year: 2018}
+	test.AssertLabel(t, "year.URL()", year.URL(), "/archive/2018")
+	test.AssertLabel(t, "year.Title()", year.Title(), "2018")
+
+	month := &archiveMonth{Year: 2018, Month: 3}
+	test.AssertLabel(t, "month.URL()", month.URL(), "/archive/2018/03")
+	test.AssertLabel(t, "month.Title()", month.Title(), "March 2018")
+}
+
+func TestAllRoutes_getArchive(t *testing.T) {
+	testRoute(t, func(helper *mocks.MockHelper, ctx *mocks.MockContext) {
+		modelsConfig()
+
+		ctx.EXPECT().URL().Return("/archive")
+		expectLayoutData(helper)
+		helper.EXPECT().RespondHTML(ctx, "archive", gomock.Any()).Do(func(ctx router.Context, templateName string, data interface{}) {
+			d, ok := data.(layoutData).ContentData.(archiveData)
+			if !ok {
+				t.Errorf("could not convert to: %v", archiveData{})
+				return
+			}
+			if len(d.Years) != 1 || d.Years[0].Year != 2017 || len(d.Years[0].Posts) != 2 {
+				t.Errorf("wrong years: %v", d.Years)
+			}
+		})
+
+		err := NewAllRoutes(helper).getArchive(ctx)
+		if err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestAllRoutes_getArchivePeriodF(t *testing.T) {
+	testCases := []struct {
+		year     int
+		month    time.Month
+		expTitle string
+		expIDs   []string
+	}{
+		{2017, 0, "2017", []string{"post2", "post1"}},
+		{2017, 8, "August 2017", []string{"post2", "post1"}},
+		{2017, 7, "", nil},
+		{2016, 0, "", nil},
+	}
+
+	for testCaseIndex, tc := range testCases {
+		testRoute(t, func(helper *mocks.MockHelper, ctx *mocks.MockContext) {
+			context := test.NewContext().SetFields(test.ContextFields{
+				"index": testCaseIndex,
+				"year":  tc.year,
+				"month": tc.month,
+			})
+
+			modelsConfig()
+
+			exists := tc.expIDs != nil
+			if exists {
+				ctx.EXPECT().URL().Return("/archive/2017")
+				expectLayoutData(helper)
+				helper.EXPECT().RespondHTML(ctx, "archive_period", gomock.Any()).Do(func(ctx router.Context, templateName string, data interface{}) {
+					layoutD := data.(layoutData)
+					if layoutD.Title != tc.expTitle {
+						t.Error(context.GotExpString("layoutD.Title", layoutD.Title, tc.expTitle))
+					}
+					d, ok := layoutD.ContentData.(archivePeriodData)
+					if !ok {
+						t.Error(context.Stringf("could not convert to: %v", archivePeriodData{}))
+						return
+					}
+					ids := make([]string, len(d.Posts))
+					for i, post := range d.Posts {
+						ids[i] = post.ID()
+					}
+					if !cmp.Equal(ids, tc.expIDs) {
+						t.Error(context.GotExpString("ids", ids, tc.expIDs))
+					}
+				})
+			}
+
+			err := NewAllRoutes(helper).getArchivePeriodF(tc.year, tc.month)(ctx)
+			if exists && err != nil {
+				t.Error(context.String(err))
+			}
+			if !exists && err == nil {
+				t.Error(context.String("no error for empty archive period"))
+			}
+		})
+	}
+}
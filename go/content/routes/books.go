@@ -0,0 +1,158 @@
+package routes
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/s12chung/go_homepage/go/content/reading"
+
+	"github.com/s12chung/gostatic/go/lib/router"
+)
+
+// reviewedBooks are the read books with review pages, books with the same slug as a previous book
+// or another page under reading.URL are skipped
+func (routes *AllRoutes) reviewedBooks(log logrus.FieldLogger) ([]*reading.Book, error) {
+	books, err := routes.books(log)
+	if err != nil {
+		return nil, err
+	}
+
+	var reviewedBooks []*reading.Book
+	slugs := map[string]bool{
+		"covers":     true,
+		"highlights": true,
+		"shelves":    true,
+		"stats":      true,
+	}
+	for _, book := range reading.ReadBooks(books) {
+		if !book.HasReview() {
+			continue
+		}
+		if slugs[book.Slug()] {
+			log.Warnf("skipping the review page of %v, the URL is taken by another book: %v", book.Title, book.URL())
+			continue
+		}
+		slugs[book.Slug()] = true
+		reviewedBooks = append(reviewedBooks, book)
+	}
+	return reviewedBooks, nil
+}
+
+func (routes *AllRoutes) reviewedBook(log logrus.FieldLogger, slug string) (*reading.Book, error) {
+	books, err := routes.reviewedBooks(log)
+	if err != nil {
+		return nil, err
+	}
+	for _, book := range books {
+		if book.Slug() == slug {
+			return book, nil
+		}
+	}
+	return nil, fmt.Errorf("no reviewed book with slug: %v", slug)
+}
+
+func (routes *AllRoutes) setBookRoutes(r router.Router) error {
+	log := routes.h.Log()
+	books, err := routes.reviewedBooks(log)
+	if err != nil {
+		return err
+	}
+
+	for _, book := range books {
+		r.GetHTML(book.URL(), routes.getBookF(book.Slug()))
+
+		coverPath, err := routes.coverPath(log, book)
+		if err != nil {
+			return err
+		}
+		if coverPath != "" {
+			r.Get(reading.CoverURL(book), routes.getBookCoverF(book.Slug()))
+		}
+	}
+	return nil
+}
+
+type bookData struct {
+	Book       *reading.Book
+	CoverURL   string
+	Highlights []*reading.Highlight
+}
+
+func (routes *AllRoutes) getBookF(slug string) func(ctx router.Context) error {
+	return func(ctx router.Context) error {
+		book, err := routes.reviewedBook(ctx.Log(), slug)
+		if err != nil {
+			return err
+		}
+		coverPath, err := routes.coverPath(ctx.Log(), book)
+		if err != nil {
+			return err
+		}
+
+		highlights, err := routes.highlightsOf(ctx.Log(), book)
+		if err != nil {
+			return err
+		}
+
+		data := bookData{Book: book, Highlights: highlights}
+		layoutD := routes.newLayoutData(ctx, book.Title, nil)
+		layoutD.Type = articleType
+		layoutD.Description = fmt.Sprintf("Review of %v by %v", book.Title, strings.Join(book.Authors, ", "))
+		if coverPath != "" {
+			data.CoverURL = reading.CoverURL(book)
+			layoutD.ImageURL = routes.h.SiteSettings().AbsoluteURL(data.CoverURL)
+		}
+		layoutD.ContentData = data
+		return routes.h.RespondHTML(ctx, "book", layoutD)
+	}
+}
+
+func (routes *AllRoutes) getBookCoverF(slug string) func(ctx router.Context) error {
+	return func(ctx router.Context) error {
+		book, err := routes.reviewedBook(ctx.Log(), slug)
+		if err != nil {
+			return err
+		}
+		coverPath, err := routes.coverPath(ctx.Log(), book)
+		if err != nil {
+			return err
+		}
+		if coverPath == "" {
+			return fmt.Errorf("no cover for book: %v", book.Title)
+		}
+
+		bytes, err := ioutil.ReadFile(coverPath)
+		if err != nil {
+			return err
+		}
+		ctx.Respond(bytes)
+		return nil
+	}
+}
+
+func readingShelfURL(shelf string) string {
+	return reading.URL + "/shelves/" + reading.Slug(shelf)
+}
+
+func (routes *AllRoutes) setReadingShelfRoutes(r router.Router) error {
+	if !routes.h.ReadingSettings().ShelfPages {
+		return nil
+	}
+	books, err := routes.sortedReadBooks(routes.h.Log())
+	if err != nil {
+		return err
+	}
+	for _, shelf := range reading.Shelves(books) {
+		r.GetHTML(readingShelfURL(shelf), routes.getReadingShelfF(shelf))
+	}
+	return nil
+}
+
+func (routes *AllRoutes) getReadingShelfF(shelf string) func(ctx router.Context) error {
+	return func(ctx router.Context) error {
+		return routes.respondReading(ctx, "Reading: "+shelf, shelf)
+	}
+}
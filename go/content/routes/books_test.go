@@ -0,0 +1,243 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+
+	"github.com/s12chung/gostatic/go/lib/router"
+	"github.com/s12chung/gostatic/go/test"
+
+	"github.com/s12chung/gostatic-packages/atom"
+
+	postsatom "github.com/s12chung/go_homepage/go/content/atom"
+	"github.com/s12chung/go_homepage/go/content/reading"
+	"github.com/s12chung/go_homepage/go/test/mocks"
+)
+
+const testCoverURL = "/reading/covers/the-organization-man.jpg"
+
+// testBooksSettings uses books.yml, where only The Organization Man has a review and
+// The Organization Man and Sapiens have highlights
+func testBooksSettings(t *testing.T) (*reading.Settings, func()) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write([]byte("cover"))
+		if err != nil {
+			t.Error(err)
+		}
+	}))
+
+	settings := reading.DefaultSettings()
+	settings.Source = reading.YAMLSource
+	settings.YAMLPath = path.Join("../reading", test.FixturePath, "books.yml")
+	settings.CoverURL = server.URL + "/%v.jpg"
+	settings.KindleClippingsPath = path.Join("../reading", test.FixturePath, "My Clippings.txt")
+	settings.ReadwiseCSVPath = path.Join("../reading", test.FixturePath, "readwise_export.csv")
+	cachePath, clean := test.SandboxDir(t, settings.CoverCachePath)
+	settings.CoverCachePath = cachePath
+	return settings, func() {
+		clean()
+		server.Close()
+	}
+}
+
+func expectBooks(t *testing.T, helper *mocks.MockHelper, ctx *mocks.MockContext) func() {
+	settings, clean := testBooksSettings(t)
+	log, _ := logTest.NewNullLogger()
+	helper.EXPECT().ReadingSettings().Return(settings).AnyTimes()
+	helper.EXPECT().GoodreadsSettings().Return(nil).AnyTimes()
+	helper.EXPECT().Log().Return(log).AnyTimes()
+	ctx.EXPECT().Log().Return(log).AnyTimes()
+	return clean
+}
+
+func TestAllRoutes_setBookRoutes(t *testing.T) {
+	testRoute(t, func(helper *mocks.MockHelper, ctx *mocks.MockContext) {
+		clean := expectBooks(t, helper, ctx)
+		defer clean()
+
+		log, _ := logTest.NewNullLogger()
+		r := router.NewGenerateRouter(log)
+		err := NewAllRoutes(helper).setBookRoutes(r)
+		if err != nil {
+			t.Error(err)
+		}
+
+		got := r.URLs()
+		exp := []string{"/reading/the-organization-man", testCoverURL}
+		if !cmp.Equal(got, exp) {
+			t.Error(test.NewContext().DiffString("r.URLs()", got, exp, cmp.Diff(got, exp)))
+		}
+	})
+}
+
+func TestAllRoutes_getBookF(t *testing.T) {
+	testRoute(t, func(helper *mocks.MockHelper, ctx *mocks.MockContext) {
+		clean := expectBooks(t, helper, ctx)
+		defer clean()
+
+		ctx.EXPECT().URL().Return("/reading/the-organization-man")
+		expectLayoutData(helper)
+		helper.EXPECT().RespondHTML(ctx, "book", gomock.Any()).Do(func(ctx router.Context, templateName string, data interface{}) {
+			layoutD := data.(layoutData)
+			test.AssertLabel(t, "Title", layoutD.Title, "The Organization Man")
+			test.AssertLabel(t, "Description", layoutD.Description, "Review of The Organization Man by William H. Whyte")
+			test.AssertLabel(t, "ImageURL", layoutD.ImageURL, "https://test.com"+testCoverURL)
+
+			d := layoutD.ContentData.(bookData)
+			test.AssertLabel(t, "CoverURL", d.CoverURL, testCoverURL)
+			test.AssertLabel(t, "Book.Title", d.Book.Title, "The Organization Man")
+			test.AssertLabel(t, "len(Highlights)", len(d.Highlights), 1)
+		})
+
+		err := NewAllRoutes(helper).getBookF("the-organization-man")(ctx)
+		if err != nil {
+			t.Error(err)
+		}
+
+		err = NewAllRoutes(helper).getBookF("sapiens")(ctx)
+		if err == nil {
+			t.Error("no error for book without review")
+		}
+	})
+}
+
+func TestAllRoutes_getBookCoverF(t *testing.T) {
+	testRoute(t, func(helper *mocks.MockHelper, ctx *mocks.MockContext) {
+		clean := expectBooks(t, helper, ctx)
+		defer clean()
+
+		ctx.EXPECT().Respond([]byte("cover"))
+		err := NewAllRoutes(helper).getBookCoverF("the-organization-man")(ctx)
+		if err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestAllRoutes_getReadingAtom(t *testing.T) {
+	testRoute(t, func(helper *mocks.MockHelper, ctx *mocks.MockContext) {
+		clean := expectBooks(t, helper, ctx)
+		defer clean()
+
+		helper.EXPECT().SiteSettings().Return(testSiteSettings()).AnyTimes()
+		helper.EXPECT().ManifestURL("images/logo.png").Return(testLogoURL)
+		helper.EXPECT().RespondAtom(ctx, "reading", "https://test.com"+testLogoURL, gomock.Any(), nil).
+			Do(func(ctx router.Context, feedName, logoURL string, htmlEntries []*atom.HTMLEntry, history *postsatom.History) {
+				ids := make([]string, len(htmlEntries))
+				for i, htmlEntry := range htmlEntries {
+					ids[i] = htmlEntry.ID
+				}
+				exp := []string{"https://test.com/reading#the-organization-man"}
+				if !cmp.Equal(ids, exp) {
+					t.Error(test.NewContext().GotExpString("ids", ids, exp))
+				}
+			})
+
+		err := NewAllRoutes(helper).getReadingAtom(ctx)
+		if err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestAllRoutes_getReading_SectionsAndShelves(t *testing.T) {
+	testCases := []struct {
+		shelf       string
+		expTitle    string
+		expBooks    int
+		expSections int
+		expShelves  []*readingShelf
+	}{
+		{"", "Reading", 1, 1, []*readingShelf{{"history", "/reading/shelves/history"}}},
+		{"history", "Reading: history", 1, 0, nil},
+	}
+
+	for testCaseIndex, tc := range testCases {
+		testRoute(t, func(helper *mocks.MockHelper, ctx *mocks.MockContext) {
+			context := test.NewContext().SetFields(test.ContextFields{
+				"index": testCaseIndex,
+				"shelf": tc.shelf,
+			})
+
+			settings, clean := testBooksSettings(t)
+			defer clean()
+			settings.ExtraShelves = []string{reading.CurrentlyReadingShelf}
+			settings.ShelfPages = true
+			log, _ := logTest.NewNullLogger()
+			helper.EXPECT().ReadingSettings().Return(settings).AnyTimes()
+			helper.EXPECT().GoodreadsSettings().Return(nil).AnyTimes()
+			helper.EXPECT().Log().Return(log).AnyTimes()
+			ctx.EXPECT().Log().Return(log).AnyTimes()
+
+			ctx.EXPECT().URL().Return(readingShelfURL(tc.shelf))
+			expectLayoutData(helper)
+			helper.EXPECT().RespondHTML(ctx, "reading", gomock.Any()).Do(func(ctx router.Context, templateName string, data interface{}) {
+				layoutD := data.(layoutData)
+				if layoutD.Title != tc.expTitle {
+					t.Error(context.GotExpString("Title", layoutD.Title, tc.expTitle))
+				}
+				d := layoutD.ContentData.(readingData)
+				if len(d.Books) != tc.expBooks {
+					t.Error(context.GotExpString("len(d.Books)", len(d.Books), tc.expBooks))
+				}
+				if len(d.Sections) != tc.expSections {
+					t.Error(context.GotExpString("len(d.Sections)", len(d.Sections), tc.expSections))
+				}
+				if len(d.Sections) == 1 && (d.Sections[0].Title != "Currently Reading" || d.Sections[0].Books[0].Title != "Sapiens") {
+					t.Error(context.Stringf("wrong section: %v", d.Sections[0]))
+				}
+				if !cmp.Equal(d.Shelves, tc.expShelves) {
+					t.Error(context.DiffString("d.Shelves", d.Shelves, tc.expShelves, cmp.Diff(d.Shelves, tc.expShelves)))
+				}
+			})
+
+			var err error
+			if tc.shelf == "" {
+				err = NewAllRoutes(helper).getReading(ctx)
+			} else {
+				err = NewAllRoutes(helper).getReadingShelfF(tc.shelf)(ctx)
+			}
+			if err != nil {
+				t.Error(context.String(err))
+			}
+
+			r := router.NewGenerateRouter(log)
+			err = NewAllRoutes(helper).setReadingShelfRoutes(r)
+			if err != nil {
+				t.Error(context.String(err))
+			}
+			exp := []string{"/reading/shelves/history"}
+			if !cmp.Equal(r.URLs(), exp) {
+				t.Error(context.GotExpString("r.URLs()", r.URLs(), exp))
+			}
+		})
+	}
+}
+
+func TestAllRoutes_getPostF_ReferencedBooks(t *testing.T) {
+	testRoute(t, func(helper *mocks.MockHelper, ctx *mocks.MockContext) {
+		clean := expectBooks(t, helper, ctx)
+		defer clean()
+
+		helper.EXPECT().WebmentionSettings().Return(testWebmentionSettings())
+		ctx.EXPECT().URL().Return("/post2")
+		expectLayoutData(helper)
+		helper.EXPECT().RespondHTML(ctx, "post", gomock.Any()).Do(func(ctx router.Context, templateName string, data interface{}) {
+			d := data.(layoutData).ContentData.(postData)
+			if len(d.ReferencedBooks) != 1 || d.ReferencedBooks[0].Title != "The Organization Man" {
+				t.Errorf("wrong ReferencedBooks: %v", d.ReferencedBooks)
+			}
+		})
+
+		err := NewAllRoutes(helper).getPostF("post2")(ctx)
+		if err != nil {
+			t.Error(err)
+		}
+	})
+}
@@ -0,0 +1,83 @@
+package routes
+
+import (
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/s12chung/go_homepage/go/content/reading"
+	"github.com/s12chung/go_homepage/go/content/webmention"
+)
+
+// buildCache has the book list, the book covers and the received webmentions, so they are loaded once and shared
+// by the route groups of a build.
+// Routes are generated concurrently, so it is locked
+type buildCache struct {
+	mutex sync.Mutex
+
+	booksLoaded bool
+	books       []*reading.Book
+	booksErr    error
+
+	coverPaths map[string]string
+
+	mentionsLoaded bool
+	mentions       []*webmention.Mention
+	mentionsErr    error
+}
+
+func newBuildCache() *buildCache {
+	return &buildCache{coverPaths: map[string]string{}}
+}
+
+// books is the book list of the reading source, the books are shared, so they must not be changed
+func (routes *AllRoutes) books(log logrus.FieldLogger) ([]*reading.Book, error) {
+	cache := routes.cache
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if !cache.booksLoaded {
+		cache.books, cache.booksErr = routes.sourceBooks(log)
+		cache.booksLoaded = true
+	}
+	return cache.books, cache.booksErr
+}
+
+func (routes *AllRoutes) sourceBooks(log logrus.FieldLogger) ([]*reading.Book, error) {
+	source, err := reading.NewSource(routes.h.ReadingSettings(), routes.h.GoodreadsSettings(), log)
+	if err != nil {
+		return nil, err
+	}
+	return source.Books()
+}
+
+// coverPath is the file path of the book's cover from the reading.CoverCache, "" when it has no cover
+func (routes *AllRoutes) coverPath(log logrus.FieldLogger, book *reading.Book) (string, error) {
+	cache := routes.cache
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	coverPath, exists := cache.coverPaths[book.Slug()]
+	if exists {
+		return coverPath, nil
+	}
+	coverPath, err := reading.NewCoverCache(routes.h.ReadingSettings(), log).Path(book)
+	if err != nil {
+		return "", err
+	}
+	cache.coverPaths[book.Slug()] = coverPath
+	return coverPath, nil
+}
+
+// receivedMentions are the received webmentions of every post, they are shared, so they must not be changed
+func (routes *AllRoutes) receivedMentions() ([]*webmention.Mention, error) {
+	cache := routes.cache
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if !cache.mentionsLoaded {
+		cache.mentions, cache.mentionsErr = webmention.ReceivedMentions(routes.h.WebmentionSettings())
+		cache.mentionsLoaded = true
+	}
+	return cache.mentions, cache.mentionsErr
+}
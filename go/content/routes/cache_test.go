@@ -0,0 +1,62 @@
+package routes
+
+import (
+	"testing"
+
+	logTest "github.com/sirupsen/logrus/hooks/test"
+
+	"github.com/s12chung/go_homepage/go/content/reading"
+	"github.com/s12chung/go_homepage/go/content/webmention"
+	"github.com/s12chung/go_homepage/go/test/mocks"
+)
+
+func TestAllRoutes_books(t *testing.T) {
+	testRoute(t, func(helper *mocks.MockHelper, ctx *mocks.MockContext) {
+		settings, clean := testBooksSettings(t)
+		defer clean()
+		// once for the source and once for the cover cache
+		helper.EXPECT().ReadingSettings().Return(settings).Times(2)
+		helper.EXPECT().GoodreadsSettings().Return(nil).Times(1)
+
+		log, _ := logTest.NewNullLogger()
+		routes := NewAllRoutes(helper)
+		var got [][]*reading.Book
+		for i := 0; i < 2; i++ {
+			books, err := routes.books(log)
+			if err != nil {
+				t.Error(err)
+			}
+			got = append(got, books)
+
+			coverPath, err := routes.coverPath(log, books[0])
+			if err != nil {
+				t.Error(err)
+			}
+			if coverPath == "" {
+				t.Error("coverPath is empty")
+			}
+		}
+		if len(got[0]) == 0 || got[0][0] != got[1][0] {
+			t.Error("books are not the same loaded books")
+		}
+	})
+}
+
+func TestAllRoutes_receivedMentions(t *testing.T) {
+	testRoute(t, func(helper *mocks.MockHelper, ctx *mocks.MockContext) {
+		helper.EXPECT().WebmentionSettings().Return(testWebmentionSettings()).Times(1)
+
+		routes := NewAllRoutes(helper)
+		var got [][]*webmention.Mention
+		for i := 0; i < 2; i++ {
+			mentions, err := routes.receivedMentions()
+			if err != nil {
+				t.Error(err)
+			}
+			got = append(got, mentions)
+		}
+		if len(got[0]) == 0 || got[0][0] != got[1][0] {
+			t.Error("mentions are not the same loaded mentions")
+		}
+	})
+}
@@ -0,0 +1,119 @@
+package routes
+
+import (
+	"github.com/s12chung/go_homepage/go/content/atom"
+	"github.com/s12chung/go_homepage/go/content/models"
+	"github.com/s12chung/go_homepage/go/content/reading"
+
+	"github.com/s12chung/gostatic/go/app"
+	"github.com/s12chung/gostatic/go/lib/router"
+)
+
+// PostsRoutes are the home page, posts, translations and archive pages
+type PostsRoutes struct {
+	*AllRoutes
+}
+
+func NewPostsRoutes(all *AllRoutes) *PostsRoutes {
+	return &PostsRoutes{all}
+}
+
+func (routes *PostsRoutes) SetRoutes(r router.Router, tracker *app.Tracker) error {
+	r.GetRootHTML(routes.getPosts)
+	tracker.AddDependentURL(router.RootURL)
+
+	r.GetHTML(archiveURL, routes.getArchive)
+	tracker.AddDependentURL(archiveURL)
+	err := routes.setArchivePeriodRoutes(r, tracker)
+	if err != nil {
+		return err
+	}
+
+	allPostFilenames, err := models.AllPostFilenames()
+	if err != nil {
+		return err
+	}
+	for _, filename := range allPostFilenames {
+		post, err := models.NewPost(filename)
+		if err != nil {
+			return err
+		}
+		if post.Lang == "" {
+			r.GetHTML(filename, routes.getPostF(filename))
+		} else {
+			r.GetHTML(post.URL(), routes.getPostF(filename))
+		}
+	}
+	return routes.setLangRoutes(r, tracker)
+}
+
+// FeedsRoutes are the atom feeds of posts
+type FeedsRoutes struct {
+	*AllRoutes
+}
+
+func NewFeedsRoutes(all *AllRoutes) *FeedsRoutes {
+	return &FeedsRoutes{all}
+}
+
+func (routes *FeedsRoutes) SetRoutes(r router.Router, tracker *app.Tracker) error {
+	r.Get(atom.CurrentURL, routes.getPostsAtom)
+	tracker.AddDependentURL(atom.CurrentURL)
+	err := routes.setPostsArchiveAtomRoutes(r, tracker, "")
+	if err != nil {
+		return err
+	}
+	return routes.setLangFeedRoutes(r, tracker)
+}
+
+// ReadingRoutes are /reading with its books, shelves, stats, highlights and atom feed
+type ReadingRoutes struct {
+	*AllRoutes
+}
+
+func NewReadingRoutes(all *AllRoutes) *ReadingRoutes {
+	return &ReadingRoutes{all}
+}
+
+func (routes *ReadingRoutes) SetRoutes(r router.Router, tracker *app.Tracker) error {
+	r.GetHTML(reading.URL, routes.getReading)
+	r.Get(atom.ReadingURL, routes.getReadingAtom)
+	r.GetHTML(readingStatsURL, routes.getReadingStats)
+	r.GetHTML(readingHighlightsURL, routes.getReadingHighlights)
+	err := routes.setReadingShelfRoutes(r)
+	if err != nil {
+		return err
+	}
+	r.Get(readingStatsJSONURL, routes.getReadingStatsJSON)
+	return routes.setBookRoutes(r)
+}
+
+// PagesRoutes are the markdown pages
+type PagesRoutes struct {
+	*AllRoutes
+}
+
+func NewPagesRoutes(all *AllRoutes) *PagesRoutes {
+	return &PagesRoutes{all}
+}
+
+func (routes *PagesRoutes) SetRoutes(r router.Router, tracker *app.Tracker) error {
+	return routes.setPageRoutes(r)
+}
+
+// SystemRoutes are robots.txt, sitemap.xml and 404.html
+type SystemRoutes struct {
+	*AllRoutes
+}
+
+func NewSystemRoutes(all *AllRoutes) *SystemRoutes {
+	return &SystemRoutes{all}
+}
+
+func (routes *SystemRoutes) SetRoutes(r router.Router, tracker *app.Tracker) error {
+	r.Get("/robots.txt", routes.getRobotsTxt)
+	r.Get("/sitemap.xml", routes.getSitemap)
+	tracker.AddDependentURL("/sitemap.xml")
+	r.Get("/404.html", routes.get404)
+	return nil
+}
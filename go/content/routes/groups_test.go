@@ -0,0 +1,184 @@
+package routes
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+
+	"github.com/s12chung/gostatic/go/app"
+	"github.com/s12chung/gostatic/go/lib/router"
+	"github.com/s12chung/gostatic/go/test"
+
+	"github.com/s12chung/go_homepage/go/content/groups"
+	"github.com/s12chung/go_homepage/go/content/models"
+	"github.com/s12chung/go_homepage/go/content/nav"
+	"github.com/s12chung/go_homepage/go/test/mocks"
+)
+
+type routeGroup interface {
+	SetRoutes(r router.Router, tracker *app.Tracker) error
+}
+
+func TestRouteGroups(t *testing.T) {
+	testCases := []struct {
+		name     string
+		newGroup func(h Helper) routeGroup
+		exp      []string
+	}{
+		{"posts", func(h Helper) routeGroup { return NewPostsRoutes(NewAllRoutes(h)) }, []string{
+			"/", "/archive", "/archive/2017", "/archive/2017/08", "/fr/post1", "post1", "post2", "draft1", "draft2", "draft3", "/fr",
+		}},
+		{"feeds", func(h Helper) routeGroup { return NewFeedsRoutes(NewAllRoutes(h)) }, []string{"/posts.atom", "/fr/posts.atom"}},
+		{"reading", func(h Helper) routeGroup { return NewReadingRoutes(NewAllRoutes(h)) }, []string{
+			"/reading", "/reading.atom", "/reading/stats", "/reading/highlights", "/reading/stats.json", "/reading/the-organization-man", testCoverURL,
+		}},
+		{"pages", func(h Helper) routeGroup { return NewPagesRoutes(NewAllRoutes(h)) }, []string{"/about", "/now", "/uses"}},
+		{"system", func(h Helper) routeGroup { return NewSystemRoutes(NewAllRoutes(h)) }, []string{"/robots.txt", "/sitemap.xml", "/404.html"}},
+	}
+
+	for testCaseIndex, tc := range testCases {
+		testRoute(t, func(helper *mocks.MockHelper, ctx *mocks.MockContext) {
+			context := test.NewContext().SetFields(test.ContextFields{
+				"index": testCaseIndex,
+				"name":  tc.name,
+			})
+
+			modelsConfig()
+			clean := expectBooks(t, helper, ctx)
+			defer clean()
+			helper.EXPECT().SiteSettings().Return(testSiteSettings()).AnyTimes()
+
+			log, _ := logTest.NewNullLogger()
+			r := router.NewGenerateRouter(log)
+			tracker := app.NewTracker(func() []string { return nil })
+			err := tc.newGroup(helper).SetRoutes(r, tracker)
+			if err != nil {
+				t.Error(context.String(err))
+			}
+
+			got := r.URLs()
+			if !cmp.Equal(got, tc.exp) {
+				t.Error(context.DiffString("r.URLs()", got, tc.exp, cmp.Diff(got, tc.exp)))
+			}
+		})
+	}
+}
+
+// testOnlyPostsGroups disables every group except posts
+func testOnlyPostsGroups() *groups.Settings {
+	settings := groups.DefaultSettings()
+	settings.Feeds = false
+	settings.Reading = false
+	settings.Pages = false
+	return settings
+}
+
+func TestAllRoutes_getSitemap_DisabledGroups(t *testing.T) {
+	testRoute(t, func(helper *mocks.MockHelper, ctx *mocks.MockContext) {
+		modelsConfig()
+		helper.EXPECT().GroupsSettings().Return(testOnlyPostsGroups())
+		helper.EXPECT().SiteSettings().Return(testSiteSettings())
+		ctx.EXPECT().Respond(gomock.Any()).Do(func(bytes []byte) {
+			got := string(bytes)
+			exp := `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url>
+    <loc>https://test.com/</loc>
+  </url>
+  <url>
+    <loc>https://test.com/archive</loc>
+  </url>
+  <url>
+    <loc>https://test.com/fr/post1</loc>
+    <lastmod>2017-08-03</lastmod>
+  </url>
+  <url>
+    <loc>https://test.com/post2</loc>
+    <lastmod>2017-08-02</lastmod>
+  </url>
+  <url>
+    <loc>https://test.com/post1</loc>
+    <lastmod>2017-08-01</lastmod>
+  </url>
+</urlset>`
+			if got != exp {
+				t.Error(test.NewContext().GotExpString("Result", got, exp))
+			}
+		})
+
+		err := NewAllRoutes(helper).getSitemap(ctx)
+		if err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestAllRoutes_navItems_DisabledGroups(t *testing.T) {
+	testRoute(t, func(helper *mocks.MockHelper, ctx *mocks.MockContext) {
+		modelsConfig()
+		settings := nav.DefaultSettings()
+		settings.Items = append(settings.Items, &nav.Item{Label: "Home", URL: "/"})
+		helper.EXPECT().GroupsSettings().Return(testOnlyPostsGroups())
+		helper.EXPECT().NavSettings().Return(settings)
+
+		got, err := NewAllRoutes(helper).navItems("/")
+		if err != nil {
+			t.Error(err)
+		}
+		exp := []*navItem{{"Home", "/", false, true}}
+		if !cmp.Equal(got, exp) {
+			t.Error(test.NewContext().DiffString("Result", got, exp, cmp.Diff(got, exp)))
+		}
+	})
+}
+
+func TestAllRoutes_feedURL(t *testing.T) {
+	testCases := []struct {
+		feeds bool
+		exp   string
+	}{
+		{true, "https://test.com/posts.atom"},
+		{false, ""},
+	}
+
+	for testCaseIndex, tc := range testCases {
+		testRoute(t, func(helper *mocks.MockHelper, ctx *mocks.MockContext) {
+			context := test.NewContext().SetFields(test.ContextFields{
+				"index": testCaseIndex,
+				"feeds": tc.feeds,
+			})
+
+			settings := groups.DefaultSettings()
+			settings.Feeds = tc.feeds
+			helper.EXPECT().GroupsSettings().Return(settings)
+			helper.EXPECT().SiteSettings().Return(testSiteSettings()).AnyTimes()
+
+			got := NewAllRoutes(helper).feedURL("/posts.atom")
+			if got != tc.exp {
+				t.Error(context.GotExpString("Result", got, tc.exp))
+			}
+		})
+	}
+}
+
+func TestAllRoutes_referencedBooks_ReadingDisabled(t *testing.T) {
+	testRoute(t, func(helper *mocks.MockHelper, ctx *mocks.MockContext) {
+		modelsConfig()
+		helper.EXPECT().GroupsSettings().Return(testOnlyPostsGroups())
+
+		post, err := models.NewPost("post2")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		got, err := NewAllRoutes(helper).referencedBooks(ctx, post)
+		if err != nil {
+			t.Error(err)
+		}
+		if got != nil {
+			t.Error(test.NewContext().GotExpString("Result", got, nil))
+		}
+	})
+}
@@ -4,6 +4,15 @@ import (
 	"path"
 	"strings"
 
+	"github.com/sirupsen/logrus"
+
+	postsatom "github.com/s12chung/go_homepage/go/content/atom"
+	"github.com/s12chung/go_homepage/go/content/groups"
+	"github.com/s12chung/go_homepage/go/content/nav"
+	"github.com/s12chung/go_homepage/go/content/reading"
+	"github.com/s12chung/go_homepage/go/content/site"
+	"github.com/s12chung/go_homepage/go/content/webmention"
+
 	"github.com/s12chung/gostatic/go/lib/html"
 	"github.com/s12chung/gostatic/go/lib/router"
 	"github.com/s12chung/gostatic/go/lib/webpack"
@@ -14,35 +23,83 @@ import (
 
 type Helper interface {
 	ManifestURL(key string) string
-	RespondAtom(ctx router.Context, feedName, logoURL string, htmlEntries []*atom.HTMLEntry) error
+	RespondAtom(ctx router.Context, feedName, logoURL string, htmlEntries []*atom.HTMLEntry, history *postsatom.History) error
 	RespondHTML(ctx router.Context, templateName string, data interface{}) error
+	SiteSettings() *site.Settings
+	GroupsSettings() *groups.Settings
+	NavSettings() *nav.Settings
+	ReadingSettings() *reading.Settings
 	GoodreadsSettings() *goodreads.Settings
+	AtomSettings() *atom.Settings
+	WebmentionSettings() *webmention.Settings
+	Log() logrus.FieldLogger
 }
 
 type BaseHelper struct {
-	goodreadsSettings *goodreads.Settings
-	Webpack           *webpack.Webpack
-	HTMLRenderer      *html.Renderer
-	AtomRenderer      *atom.HTMLRenderer
+	siteSettings       *site.Settings
+	groupsSettings     *groups.Settings
+	navSettings        *nav.Settings
+	readingSettings    *reading.Settings
+	goodreadsSettings  *goodreads.Settings
+	atomSettings       *atom.Settings
+	webmentionSettings *webmention.Settings
+	Webpack            *webpack.Webpack
+	HTMLRenderer       *html.Renderer
+	AtomRenderer       *atom.HTMLRenderer
+	log                logrus.FieldLogger
 }
 
-func NewBaseHelper(goodReadSettings *goodreads.Settings, w *webpack.Webpack, htmlRenderer *html.Renderer, atomRenderer *atom.HTMLRenderer) *BaseHelper {
-	return &BaseHelper{goodReadSettings, w, htmlRenderer, atomRenderer}
+func NewBaseHelper(siteSettings *site.Settings, groupsSettings *groups.Settings, navSettings *nav.Settings, readingSettings *reading.Settings, goodReadSettings *goodreads.Settings, atomSettings *atom.Settings, webmentionSettings *webmention.Settings, w *webpack.Webpack, htmlRenderer *html.Renderer, atomRenderer *atom.HTMLRenderer, log logrus.FieldLogger) *BaseHelper {
+	return &BaseHelper{siteSettings, groupsSettings, navSettings, readingSettings, goodReadSettings, atomSettings, webmentionSettings, w, htmlRenderer, atomRenderer, log}
 }
 
 func (helper *BaseHelper) ManifestURL(key string) string {
 	return helper.Webpack.ManifestURL(key)
 }
 
+func (helper *BaseHelper) SiteSettings() *site.Settings {
+	return helper.siteSettings
+}
+
+func (helper *BaseHelper) GroupsSettings() *groups.Settings {
+	return helper.groupsSettings
+}
+
+func (helper *BaseHelper) NavSettings() *nav.Settings {
+	return helper.navSettings
+}
+
+func (helper *BaseHelper) ReadingSettings() *reading.Settings {
+	return helper.readingSettings
+}
+
 func (helper *BaseHelper) GoodreadsSettings() *goodreads.Settings {
 	return helper.goodreadsSettings
 }
 
-func (helper *BaseHelper) RespondAtom(ctx router.Context, feedName, logoURL string, htmlEntries []*atom.HTMLEntry) error {
-	bytes, err := helper.AtomRenderer.Render(feedName, ctx.URL(), logoURL, htmlEntries)
+func (helper *BaseHelper) AtomSettings() *atom.Settings {
+	return helper.atomSettings
+}
+
+func (helper *BaseHelper) WebmentionSettings() *webmention.Settings {
+	return helper.webmentionSettings
+}
+
+func (helper *BaseHelper) Log() logrus.FieldLogger {
+	return helper.log
+}
+
+func (helper *BaseHelper) RespondAtom(ctx router.Context, feedName, logoURL string, htmlEntries []*atom.HTMLEntry, history *postsatom.History) error {
+	bytes, err := helper.AtomRenderer.Render(feedName, helper.siteSettings.AbsoluteURL(ctx.URL()), logoURL, htmlEntries)
 	if err != nil {
 		return err
 	}
+	if history != nil {
+		bytes, err = history.AddTo(bytes)
+		if err != nil {
+			return err
+		}
+	}
 	ctx.Respond(bytes)
 	return nil
 }
@@ -0,0 +1,50 @@
+package routes
+
+import (
+	"github.com/sirupsen/logrus"
+
+	"github.com/s12chung/go_homepage/go/content/reading"
+
+	"github.com/s12chung/gostatic/go/lib/router"
+)
+
+const readingHighlightsURL = reading.URL + "/highlights"
+
+// bookHighlights returns the highlights grouped by book, matched to books
+func (routes *AllRoutes) bookHighlights(log logrus.FieldLogger, books []*reading.Book) ([]*reading.BookHighlights, error) {
+	highlights, err := reading.Highlights(routes.h.ReadingSettings(), log)
+	if err != nil {
+		return nil, err
+	}
+	return reading.GroupHighlights(highlights, books), nil
+}
+
+// highlightsOf returns the highlights matched to the book
+func (routes *AllRoutes) highlightsOf(log logrus.FieldLogger, book *reading.Book) ([]*reading.Highlight, error) {
+	groups, err := routes.bookHighlights(log, []*reading.Book{book})
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		if group.Book != nil {
+			return group.Highlights, nil
+		}
+	}
+	return nil, nil
+}
+
+type highlightsData struct {
+	Books []*reading.BookHighlights
+}
+
+func (routes *AllRoutes) getReadingHighlights(ctx router.Context) error {
+	books, err := routes.books(ctx.Log())
+	if err != nil {
+		return err
+	}
+	groups, err := routes.bookHighlights(ctx.Log(), books)
+	if err != nil {
+		return err
+	}
+	return routes.h.RespondHTML(ctx, "highlights", routes.newLayoutData(ctx, "Highlights", highlightsData{groups}))
+}
@@ -0,0 +1,47 @@
+package routes
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/s12chung/gostatic/go/lib/router"
+	"github.com/s12chung/gostatic/go/test"
+
+	"github.com/s12chung/go_homepage/go/test/mocks"
+)
+
+func TestAllRoutes_getReadingHighlights(t *testing.T) {
+	testRoute(t, func(helper *mocks.MockHelper, ctx *mocks.MockContext) {
+		clean := expectBooks(t, helper, ctx)
+		defer clean()
+
+		ctx.EXPECT().URL().Return(readingHighlightsURL)
+		expectLayoutData(helper)
+		helper.EXPECT().RespondHTML(ctx, "highlights", gomock.Any()).Do(func(ctx router.Context, templateName string, data interface{}) {
+			d := data.(layoutData).ContentData.(highlightsData)
+
+			var titles, matched []string
+			for _, group := range d.Books {
+				titles = append(titles, group.Title)
+				if group.Book != nil {
+					matched = append(matched, group.Title)
+				}
+			}
+			expTitles := []string{"Sapiens", "The Organization Man", "Unread Book"}
+			if !cmp.Equal(titles, expTitles) {
+				t.Error(test.NewContext().GotExpString("titles", titles, expTitles))
+			}
+			expMatched := []string{"Sapiens", "The Organization Man"}
+			if !cmp.Equal(matched, expMatched) {
+				t.Error(test.NewContext().GotExpString("matched", matched, expMatched))
+			}
+		})
+
+		err := NewAllRoutes(helper).getReadingHighlights(ctx)
+		if err != nil {
+			t.Error(err)
+		}
+	})
+}
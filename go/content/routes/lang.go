@@ -0,0 +1,101 @@
+package routes
+
+import (
+	"github.com/s12chung/go_homepage/go/content/atom"
+	"github.com/s12chung/go_homepage/go/content/models"
+
+	"github.com/s12chung/gostatic/go/app"
+	"github.com/s12chung/gostatic/go/lib/router"
+)
+
+// langURL lists the posts of a language other than the site's language
+func langURL(lang string) string {
+	return "/" + lang
+}
+
+func langFeedURL(lang string) string {
+	return postsFeedPrefix(lang) + atom.CurrentURL
+}
+
+// postsFeedPrefix prefixes the URLs of the posts feeds in lang, lang is "" for the site's language
+func postsFeedPrefix(lang string) string {
+	if lang == "" {
+		return ""
+	}
+	return langURL(lang)
+}
+
+func (routes *AllRoutes) setLangRoutes(r router.Router, tracker *app.Tracker) error {
+	langs, err := models.Langs()
+	if err != nil {
+		return err
+	}
+	for _, lang := range langs {
+		r.GetHTML(langURL(lang), routes.getLangPostsF(lang))
+		tracker.AddDependentURL(langURL(lang))
+	}
+	return nil
+}
+
+func (routes *AllRoutes) setLangFeedRoutes(r router.Router, tracker *app.Tracker) error {
+	langs, err := models.Langs()
+	if err != nil {
+		return err
+	}
+	for _, lang := range langs {
+		r.Get(langFeedURL(lang), routes.getLangPostsAtomF(lang))
+		tracker.AddDependentURL(langFeedURL(lang))
+		err = routes.setPostsArchiveAtomRoutes(r, tracker, lang)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// postAlternates returns the post and its translations, nil if there are no translations
+func (routes *AllRoutes) postAlternates(post *models.Post) ([]*alternate, error) {
+	translations, err := models.Translations(post)
+	if err != nil {
+		return nil, err
+	}
+	if len(translations) <= 1 {
+		return nil, nil
+	}
+
+	settings := routes.h.SiteSettings()
+	alternates := make([]*alternate, len(translations))
+	for i, translation := range translations {
+		lang := translation.Lang
+		if lang == "" {
+			lang = settings.Language
+		}
+		alternates[i] = &alternate{lang, settings.AbsoluteURL(translation.URL())}
+	}
+	return alternates, nil
+}
+
+type langPostsData struct {
+	Lang  string
+	Posts []*models.Post
+}
+
+func (routes *AllRoutes) getLangPostsF(lang string) func(ctx router.Context) error {
+	return func(ctx router.Context) error {
+		posts, err := sortedLangPosts(lang)
+		if err != nil {
+			return err
+		}
+
+		layoutD := routes.newLayoutData(ctx, "", langPostsData{lang, posts})
+		layoutD.Lang = lang
+		layoutD.FeedURL = routes.feedURL(langFeedURL(lang))
+		return routes.h.RespondHTML(ctx, "lang_posts", layoutD)
+	}
+}
+
+func (routes *AllRoutes) getLangPostsAtomF(lang string) func(ctx router.Context) error {
+	return func(ctx router.Context) error {
+		return routes.respondCurrentPostsAtom(ctx, lang)
+	}
+}
@@ -0,0 +1,138 @@
+package routes
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+
+	"github.com/s12chung/gostatic/go/app"
+	"github.com/s12chung/gostatic/go/lib/router"
+	"github.com/s12chung/gostatic/go/test"
+
+	postsatom "github.com/s12chung/go_homepage/go/content/atom"
+	"github.com/s12chung/go_homepage/go/content/models"
+	"github.com/s12chung/go_homepage/go/test/mocks"
+)
+
+func TestAllRoutes_getLangPostsF(t *testing.T) {
+	testRoute(t, func(helper *mocks.MockHelper, ctx *mocks.MockContext) {
+		modelsConfig()
+
+		ctx.EXPECT().URL().Return("/fr")
+		expectLayoutData(helper)
+		helper.EXPECT().RespondHTML(ctx, "lang_posts", gomock.Any()).Do(func(ctx router.Context, templateName string, data interface{}) {
+			layoutD := data.(layoutData)
+			if layoutD.Lang != "fr" {
+				t.Error(test.NewContext().GotExpString("layoutD.Lang", layoutD.Lang, "fr"))
+			}
+			expFeedURL := "https://test.com/fr/posts.atom"
+			if layoutD.FeedURL != expFeedURL {
+				t.Error(test.NewContext().GotExpString("layoutD.FeedURL", layoutD.FeedURL, expFeedURL))
+			}
+
+			d := layoutD.ContentData.(langPostsData)
+			ids := make([]string, len(d.Posts))
+			for i, post := range d.Posts {
+				ids[i] = post.ID()
+			}
+			exp := []string{"post1.fr"}
+			if !cmp.Equal(ids, exp) {
+				t.Error(test.NewContext().GotExpString("ids", ids, exp))
+			}
+		})
+
+		err := NewAllRoutes(helper).getLangPostsF("fr")(ctx)
+		if err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestAllRoutes_getLangPostsAtomF(t *testing.T) {
+	testCases := []struct {
+		entryLimit int
+		expHistory *postsatom.History
+	}{
+		{100, nil},
+		{1, &postsatom.History{PrevArchive: "https://test.com/fr/posts-archive-1.atom"}},
+	}
+
+	for testCaseIndex, tc := range testCases {
+		testRoute(t, func(helper *mocks.MockHelper, ctx *mocks.MockContext) {
+			context := test.NewContext().SetFields(test.ContextFields{
+				"index":      testCaseIndex,
+				"entryLimit": tc.entryLimit,
+			})
+
+			modelsConfig()
+			settings := testSiteSettings()
+			settings.FeedEntryLimit = tc.entryLimit
+			expectPostsAtom(t, context, helper, ctx, settings, []string{"https://test.com/fr/post1"}, tc.expHistory)
+
+			err := NewAllRoutes(helper).getLangPostsAtomF("fr")(ctx)
+			if err != nil {
+				t.Error(context.String(err))
+			}
+		})
+	}
+}
+
+func TestAllRoutes_setLangFeedRoutes(t *testing.T) {
+	testRoute(t, func(helper *mocks.MockHelper, ctx *mocks.MockContext) {
+		modelsConfig()
+		settings := testSiteSettings()
+		settings.FeedEntryLimit = 1
+		helper.EXPECT().SiteSettings().Return(settings).AnyTimes()
+
+		log, _ := logTest.NewNullLogger()
+		r := router.NewGenerateRouter(log)
+		err := NewAllRoutes(helper).setLangFeedRoutes(r, app.NewTracker(func() []string { return nil }))
+		if err != nil {
+			t.Error(err)
+		}
+
+		got := r.URLs()
+		exp := []string{"/fr/posts.atom", "/fr/posts-archive-1.atom"}
+		if !cmp.Equal(got, exp) {
+			t.Error(test.NewContext().DiffString("r.URLs()", got, exp, cmp.Diff(got, exp)))
+		}
+	})
+}
+
+func TestAllRoutes_postAlternates(t *testing.T) {
+	testCases := []struct {
+		postFilename string
+		exp          []*alternate
+	}{
+		{"post1", []*alternate{{"en", "https://test.com/post1"}, {"fr", "https://test.com/fr/post1"}}},
+		{"post1.fr", []*alternate{{"en", "https://test.com/post1"}, {"fr", "https://test.com/fr/post1"}}},
+		{"post2", nil},
+	}
+
+	for testCaseIndex, tc := range testCases {
+		testRoute(t, func(helper *mocks.MockHelper, ctx *mocks.MockContext) {
+			context := test.NewContext().SetFields(test.ContextFields{
+				"index":        testCaseIndex,
+				"postFilename": tc.postFilename,
+			})
+
+			modelsConfig()
+			helper.EXPECT().SiteSettings().Return(testSiteSettings()).AnyTimes()
+
+			post, err := models.NewPost(tc.postFilename)
+			if err != nil {
+				t.Error(context.String(err))
+				return
+			}
+			got, err := NewAllRoutes(helper).postAlternates(post)
+			if err != nil {
+				t.Error(context.String(err))
+			}
+			if !cmp.Equal(got, tc.exp) {
+				t.Error(context.DiffString("Result", got, tc.exp, cmp.Diff(got, tc.exp)))
+			}
+		})
+	}
+}
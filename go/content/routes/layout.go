@@ -1,6 +1,34 @@
 package routes
 
+const (
+	websiteType = "website"
+	articleType = "article"
+
+	summaryCard      = "summary"
+	largeSummaryCard = "summary_large_image"
+)
+
+// alternate is a translation of the page, for hreflang
+type alternate struct {
+	Lang string
+	URL  string
+}
+
 type layoutData struct {
-	Title       string
+	Title         string
+	Description   string
+	URL           string
+	Type          string
+	ImageURL      string
+	TwitterCard   string
+	TwitterHandle string
+	Lang          string
+	FeedURL       string
+	Alternates    []*alternate
+	Nav           []*navItem
+
+	// rendered as JSON-LD
+	StructuredData []interface{}
+
 	ContentData interface{}
 }
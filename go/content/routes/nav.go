@@ -0,0 +1,55 @@
+package routes
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/s12chung/go_homepage/go/content/models"
+	"github.com/s12chung/go_homepage/go/content/nav"
+	"github.com/s12chung/go_homepage/go/content/reading"
+
+	"github.com/s12chung/gostatic/go/lib/router"
+)
+
+type navItem struct {
+	Label    string
+	URL      string
+	External bool
+	// Active is true when the current page is the item's URL or under it
+	Active bool
+}
+
+// navItems are the nav settings items and the nav pages, sorted by order, without the disabled groups
+func (routes *AllRoutes) navItems(currentURL string) ([]*navItem, error) {
+	groupsSettings := routes.h.GroupsSettings()
+	var pages []*models.Page
+	if groupsSettings.Pages {
+		var err error
+		pages, err = models.NavPages()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	items := append([]*nav.Item{}, routes.h.NavSettings().Items...)
+	for _, page := range pages {
+		items = append(items, &nav.Item{Label: page.Title, URL: page.URL(), Order: page.NavWeight})
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Order < items[j].Order })
+
+	var navItems []*navItem
+	for _, item := range items {
+		if !item.External && !groupsSettings.Reading && isActiveURL(reading.URL, item.URL) {
+			continue
+		}
+		navItems = append(navItems, &navItem{item.Label, item.URL, item.External, !item.External && isActiveURL(item.URL, currentURL)})
+	}
+	return navItems, nil
+}
+
+func isActiveURL(itemURL, currentURL string) bool {
+	if itemURL == currentURL {
+		return true
+	}
+	return itemURL != router.RootURL && strings.HasPrefix(currentURL, itemURL+"/")
+}
@@ -0,0 +1,68 @@
+package routes
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/s12chung/gostatic/go/test"
+
+	"github.com/s12chung/go_homepage/go/content/groups"
+	"github.com/s12chung/go_homepage/go/content/nav"
+	"github.com/s12chung/go_homepage/go/test/mocks"
+)
+
+func TestAllRoutes_navItems(t *testing.T) {
+	testCases := []struct {
+		currentURL string
+		exp        []*navItem
+	}{
+		{"/", []*navItem{
+			{"Home", "/", false, true},
+			{"Reading", "/reading", false, false},
+			{"Now", "/now", false, false},
+			{"About", "/about", false, false},
+			{"GitHub", "https://github.com", true, false},
+		}},
+		{"/reading/stats", []*navItem{
+			{"Home", "/", false, false},
+			{"Reading", "/reading", false, true},
+			{"Now", "/now", false, false},
+			{"About", "/about", false, false},
+			{"GitHub", "https://github.com", true, false},
+		}},
+		{"/about", []*navItem{
+			{"Home", "/", false, false},
+			{"Reading", "/reading", false, false},
+			{"Now", "/now", false, false},
+			{"About", "/about", false, true},
+			{"GitHub", "https://github.com", true, false},
+		}},
+	}
+
+	for testCaseIndex, tc := range testCases {
+		testRoute(t, func(helper *mocks.MockHelper, ctx *mocks.MockContext) {
+			context := test.NewContext().SetFields(test.ContextFields{
+				"index":      testCaseIndex,
+				"currentURL": tc.currentURL,
+			})
+
+			modelsConfig()
+			settings := nav.DefaultSettings()
+			settings.Items = append(settings.Items,
+				&nav.Item{Label: "GitHub", URL: "https://github.com", External: true, Order: 3},
+				&nav.Item{Label: "Home", URL: "/"},
+			)
+			helper.EXPECT().GroupsSettings().Return(groups.DefaultSettings())
+			helper.EXPECT().NavSettings().Return(settings)
+
+			got, err := NewAllRoutes(helper).navItems(tc.currentURL)
+			if err != nil {
+				t.Error(context.String(err))
+			}
+			if !cmp.Equal(got, tc.exp) {
+				t.Error(context.DiffString("Result", got, tc.exp, cmp.Diff(got, tc.exp)))
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package routes
+
+import (
+	"github.com/s12chung/go_homepage/go/content/models"
+
+	"github.com/s12chung/gostatic/go/lib/router"
+)
+
+// notFoundRecentCount is the number of recent posts on the 404 page
+const notFoundRecentCount = 5
+
+// notFoundPost is compact, as every post is embedded in the 404 page
+type notFoundPost struct {
+	URL   string `json:"url"`
+	Title string `json:"title"`
+}
+
+type notFoundData struct {
+	// Posts are every published post, for the script suggesting the closest match to the requested URL
+	Posts       []*notFoundPost
+	RecentPosts []*models.Post
+}
+
+func (routes *AllRoutes) get404(ctx router.Context) error {
+	data := notFoundData{[]*notFoundPost{}, nil}
+	if routes.h.GroupsSettings().Posts {
+		// includes the translated posts
+		posts, err := models.Posts()
+		if err != nil {
+			return err
+		}
+		for _, post := range sortPosts(posts) {
+			data.Posts = append(data.Posts, &notFoundPost{post.URL(), post.Title})
+		}
+
+		recentPosts, err := sortedPosts()
+		if err != nil {
+			return err
+		}
+		if len(recentPosts) > notFoundRecentCount {
+			recentPosts = recentPosts[:notFoundRecentCount]
+		}
+		data.RecentPosts = recentPosts
+	}
+	return routes.h.RespondHTML(ctx, ctx.URL(), routes.newLayoutData(ctx, "404", data))
+}
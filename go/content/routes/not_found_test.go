@@ -0,0 +1,68 @@
+package routes
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/s12chung/gostatic/go/lib/router"
+	"github.com/s12chung/gostatic/go/test"
+
+	"github.com/s12chung/go_homepage/go/test/mocks"
+)
+
+func TestAllRoutes_get404(t *testing.T) {
+	testCases := []struct {
+		postDirEmpty bool
+		expPosts     []*notFoundPost
+		expRecent    []string
+	}{
+		{true, []*notFoundPost{}, []string{}},
+		{false, []*notFoundPost{
+			{"/fr/post1", "Post1 FR"},
+			{"/post2", "Post2"},
+			{"/post1", "Post1"},
+		}, []string{"post2", "post1"}},
+	}
+
+	for testCaseIndex, tc := range testCases {
+		testRoute(t, func(helper *mocks.MockHelper, ctx *mocks.MockContext) {
+			context := test.NewContext().SetFields(test.ContextFields{
+				"index":        testCaseIndex,
+				"postDirEmpty": tc.postDirEmpty,
+			})
+
+			modelsConfig()
+			if tc.postDirEmpty {
+				setPostDirEmpty()
+			}
+
+			ctx.EXPECT().URL().Return("/404.html").Times(2)
+			expectLayoutData(helper)
+			helper.EXPECT().RespondHTML(ctx, "/404.html", gomock.Any()).Do(func(ctx router.Context, templateName string, data interface{}) {
+				layoutD := data.(layoutData)
+				if layoutD.Title != "404" {
+					t.Error(context.GotExpString("layoutD.Title", layoutD.Title, "404"))
+				}
+
+				d := layoutD.ContentData.(notFoundData)
+				if !cmp.Equal(d.Posts, tc.expPosts) {
+					t.Error(context.DiffString("d.Posts", d.Posts, tc.expPosts, cmp.Diff(d.Posts, tc.expPosts)))
+				}
+				recent := make([]string, len(d.RecentPosts))
+				for i, post := range d.RecentPosts {
+					recent[i] = post.ID()
+				}
+				if !cmp.Equal(recent, tc.expRecent) {
+					t.Error(context.GotExpString("d.RecentPosts", recent, tc.expRecent))
+				}
+			})
+
+			err := NewAllRoutes(helper).get404(ctx)
+			if err != nil {
+				t.Error(context.String(err))
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package routes
+
+import (
+	"github.com/s12chung/go_homepage/go/content/models"
+
+	"github.com/s12chung/gostatic/go/lib/router"
+)
+
+// aboutURL is the page of the person in the structured data
+const aboutURL = "/about"
+
+func (routes *AllRoutes) setPageRoutes(r router.Router) error {
+	pages, err := models.Pages()
+	if err != nil {
+		return err
+	}
+	for _, page := range pages {
+		r.GetHTML(page.URL(), routes.getPageF(page.Filename))
+	}
+	return nil
+}
+
+func (routes *AllRoutes) getPageF(filename string) func(ctx router.Context) error {
+	return func(ctx router.Context) error {
+		page, err := models.NewPage(filename)
+		if err != nil {
+			return err
+		}
+
+		data := routes.newLayoutData(ctx, page.Title, page)
+		if page.Description != "" {
+			data.Description = page.Description
+		}
+		if page.URL() == aboutURL {
+			data.StructuredData = routes.aboutStructuredData()
+		}
+		return routes.h.RespondHTML(ctx, page.Layout, data)
+	}
+}
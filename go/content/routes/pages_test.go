@@ -0,0 +1,84 @@
+package routes
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+
+	"github.com/s12chung/gostatic/go/lib/router"
+	"github.com/s12chung/gostatic/go/test"
+
+	"github.com/s12chung/go_homepage/go/content/models"
+	"github.com/s12chung/go_homepage/go/test/mocks"
+)
+
+func TestAllRoutes_setPageRoutes(t *testing.T) {
+	testRoute(t, func(helper *mocks.MockHelper, ctx *mocks.MockContext) {
+		modelsConfig()
+
+		log, _ := logTest.NewNullLogger()
+		r := router.NewGenerateRouter(log)
+		err := NewAllRoutes(helper).setPageRoutes(r)
+		if err != nil {
+			t.Error(err)
+		}
+
+		got := r.URLs()
+		exp := []string{"/about", "/now", "/uses"}
+		if !cmp.Equal(got, exp) {
+			t.Error(test.NewContext().DiffString("r.URLs()", got, exp, cmp.Diff(got, exp)))
+		}
+	})
+}
+
+func TestAllRoutes_getPageF(t *testing.T) {
+	testCases := []struct {
+		filename       string
+		expTemplate    string
+		expDescription string
+		expPerson      bool
+	}{
+		{"about", "page", "Site Description", true},
+		{"now", "page", "What I'm doing now", false},
+		{"uses", "uses", "Site Description", false},
+	}
+
+	for testCaseIndex, tc := range testCases {
+		testRoute(t, func(helper *mocks.MockHelper, ctx *mocks.MockContext) {
+			context := test.NewContext().SetFields(test.ContextFields{
+				"index":    testCaseIndex,
+				"filename": tc.filename,
+			})
+
+			modelsConfig()
+			page, err := models.NewPage(tc.filename)
+			if err != nil {
+				t.Error(context.String(err))
+				return
+			}
+
+			ctx.EXPECT().URL().Return(page.URL())
+			expectLayoutData(helper)
+			exp := testLayoutData(page.Title, page.URL(), page)
+			exp.Description = tc.expDescription
+			if tc.expPerson {
+				exp.StructuredData = []interface{}{
+					&personSchema{schemaContext, "Person", "Test Author", "https://test.com/about"},
+				}
+			}
+			helper.EXPECT().RespondHTML(ctx, tc.expTemplate, gomock.Any()).Do(func(ctx router.Context, templateName string, data interface{}) {
+				got := data.(layoutData)
+				if !cmp.Equal(got, exp) {
+					t.Error(context.DiffString("Result", got, exp, cmp.Diff(got, exp)))
+				}
+			})
+
+			err = NewAllRoutes(helper).getPageF(tc.filename)(ctx)
+			if err != nil {
+				t.Error(context.String(err))
+			}
+		})
+	}
+}
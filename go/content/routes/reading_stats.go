@@ -0,0 +1,48 @@
+package routes
+
+import (
+	"encoding/json"
+
+	"github.com/s12chung/go_homepage/go/content/reading"
+
+	"github.com/s12chung/gostatic/go/lib/router"
+)
+
+const readingStatsURL = reading.URL + "/stats"
+const readingStatsJSONURL = readingStatsURL + ".json"
+
+type readingStatsData struct {
+	Years []*reading.YearStats `json:"years"`
+	// Ratings are the rows of the ratings tables, from the highest rating
+	Ratings []int `json:"-"`
+}
+
+func (routes *AllRoutes) readingStatsData(ctx router.Context) (readingStatsData, error) {
+	books, err := routes.books(ctx.Log())
+	if err != nil {
+		return readingStatsData{}, err
+	}
+	maxRating := routes.h.ReadingSettings().MaxRating()
+	return readingStatsData{reading.YearlyStats(books, maxRating), reading.Ratings(maxRating)}, nil
+}
+
+func (routes *AllRoutes) getReadingStats(ctx router.Context) error {
+	data, err := routes.readingStatsData(ctx)
+	if err != nil {
+		return err
+	}
+	return routes.h.RespondHTML(ctx, "reading_stats", routes.newLayoutData(ctx, "Reading Statistics", data))
+}
+
+func (routes *AllRoutes) getReadingStatsJSON(ctx router.Context) error {
+	data, err := routes.readingStatsData(ctx)
+	if err != nil {
+		return err
+	}
+	bytes, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+	ctx.Respond(bytes)
+	return nil
+}
@@ -0,0 +1,101 @@
+package routes
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+
+	"github.com/s12chung/gostatic/go/lib/router"
+	"github.com/s12chung/gostatic/go/test"
+
+	"github.com/s12chung/go_homepage/go/test/mocks"
+)
+
+func TestAllRoutes_getReadingStats(t *testing.T) {
+	testRoute(t, func(helper *mocks.MockHelper, ctx *mocks.MockContext) {
+		clean := expectBooks(t, helper, ctx)
+		defer clean()
+
+		ctx.EXPECT().URL().Return(readingStatsURL)
+		expectLayoutData(helper)
+		helper.EXPECT().RespondHTML(ctx, "reading_stats", gomock.Any()).Do(func(ctx router.Context, templateName string, data interface{}) {
+			d := data.(layoutData).ContentData.(readingStatsData)
+			if len(d.Years) != 1 || d.Years[0].Year != 2018 || d.Years[0].BooksRead != 1 {
+				t.Errorf("wrong years: %v", d.Years)
+			}
+			expRatings := []int{5, 4, 3, 2, 1}
+			if !cmp.Equal(d.Ratings, expRatings) {
+				t.Error(test.NewContext().GotExpString("d.Ratings", d.Ratings, expRatings))
+			}
+		})
+
+		err := NewAllRoutes(helper).getReadingStats(ctx)
+		if err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestAllRoutes_readingStatsData_ratingScale(t *testing.T) {
+	testRoute(t, func(helper *mocks.MockHelper, ctx *mocks.MockContext) {
+		settings, clean := testBooksSettings(t)
+		defer clean()
+		settings.Legend = []string{"Great.", "Good.", "Bad."}
+		log, _ := logTest.NewNullLogger()
+		helper.EXPECT().ReadingSettings().Return(settings).AnyTimes()
+		helper.EXPECT().GoodreadsSettings().Return(nil).AnyTimes()
+		ctx.EXPECT().Log().Return(log).AnyTimes()
+
+		d, err := NewAllRoutes(helper).readingStatsData(ctx)
+		if err != nil {
+			t.Error(err)
+		}
+		expRatings := []int{3, 2, 1}
+		if !cmp.Equal(d.Ratings, expRatings) {
+			t.Error(test.NewContext().GotExpString("d.Ratings", d.Ratings, expRatings))
+		}
+		expRatingMap := map[int]int{1: 0, 2: 0, 3: 0}
+		if len(d.Years) != 1 || !cmp.Equal(d.Years[0].RatingMap, expRatingMap) {
+			t.Errorf("wrong years: %v", d.Years)
+		}
+	})
+}
+
+func TestAllRoutes_getReadingStatsJSON(t *testing.T) {
+	testRoute(t, func(helper *mocks.MockHelper, ctx *mocks.MockContext) {
+		clean := expectBooks(t, helper, ctx)
+		defer clean()
+
+		exp := `{
+  "years": [
+    {
+      "year": 2018,
+      "books_read": 1,
+      "pages_read": 0,
+      "average_rating": 4,
+      "rating_map": {
+        "1": 0,
+        "2": 0,
+        "3": 0,
+        "4": 1,
+        "5": 0
+      },
+      "repeat_authors": []
+    }
+  ]
+}`
+		ctx.EXPECT().Respond(gomock.Any()).Do(func(bytes []byte) {
+			got := string(bytes)
+			if got != exp {
+				t.Error(test.NewContext().GotExpString("Result", got, exp))
+			}
+		})
+
+		err := NewAllRoutes(helper).getReadingStatsJSON(ctx)
+		if err != nil {
+			t.Error(err)
+		}
+	})
+}
@@ -0,0 +1,80 @@
+package routes
+
+import (
+	"encoding/xml"
+
+	"github.com/s12chung/go_homepage/go/content/groups"
+	"github.com/s12chung/go_homepage/go/content/models"
+	"github.com/s12chung/go_homepage/go/content/reading"
+
+	"github.com/s12chung/gostatic/go/lib/router"
+)
+
+const sitemapXMLNS = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+type sitemapURLSet struct {
+	XMLName xml.Name      `xml:"urlset"`
+	XMLNS   string        `xml:"xmlns,attr"`
+	URLs    []*sitemapURL `xml:"url"`
+}
+
+// sitemapPageURLs are the URLs of the pages without a last modified date
+func sitemapPageURLs(groupsSettings *groups.Settings) []string {
+	var pageURLs []string
+	if groupsSettings.Posts {
+		pageURLs = append(pageURLs, router.RootURL, archiveURL)
+	}
+	if groupsSettings.Reading {
+		pageURLs = append(pageURLs, reading.URL)
+	}
+	return pageURLs
+}
+
+// getSitemap has the URLs of the enabled groups
+func (routes *AllRoutes) getSitemap(ctx router.Context) error {
+	groupsSettings := routes.h.GroupsSettings()
+	var pages []*models.Page
+	var posts []*models.Post
+	var err error
+	if groupsSettings.Pages {
+		pages, err = models.Pages()
+		if err != nil {
+			return err
+		}
+	}
+	if groupsSettings.Posts {
+		// includes the translated posts
+		posts, err = models.Posts()
+		if err != nil {
+			return err
+		}
+		posts = sortPosts(posts)
+	}
+
+	settings := routes.h.SiteSettings()
+	urlSet := sitemapURLSet{XMLNS: sitemapXMLNS}
+	for _, pageURL := range sitemapPageURLs(groupsSettings) {
+		urlSet.URLs = append(urlSet.URLs, &sitemapURL{Loc: settings.AbsoluteURL(pageURL)})
+	}
+	for _, page := range pages {
+		urlSet.URLs = append(urlSet.URLs, &sitemapURL{Loc: settings.AbsoluteURL(page.URL())})
+	}
+	for _, post := range posts {
+		urlSet.URLs = append(urlSet.URLs, &sitemapURL{
+			settings.AbsoluteURL(post.URL()),
+			post.LastUpdatedAt().Format("2006-01-02"),
+		})
+	}
+
+	bytes, err := xml.MarshalIndent(urlSet, "", "  ")
+	if err != nil {
+		return err
+	}
+	ctx.Respond(append([]byte(xml.Header), bytes...))
+	return nil
+}
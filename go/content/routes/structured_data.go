@@ -0,0 +1,94 @@
+package routes
+
+import (
+	"time"
+
+	"github.com/s12chung/go_homepage/go/content/models"
+)
+
+// structured data in JSON-LD, see https://schema.org
+const schemaContext = "https://schema.org"
+
+type personSchema struct {
+	Context string `json:"@context,omitempty"`
+	Type    string `json:"@type"`
+	Name    string `json:"name"`
+	URL     string `json:"url,omitempty"`
+}
+
+type webSiteSchema struct {
+	Context     string        `json:"@context"`
+	Type        string        `json:"@type"`
+	Name        string        `json:"name,omitempty"`
+	URL         string        `json:"url"`
+	Description string        `json:"description,omitempty"`
+	Author      *personSchema `json:"author,omitempty"`
+}
+
+type blogPostingSchema struct {
+	Context          string        `json:"@context"`
+	Type             string        `json:"@type"`
+	Headline         string        `json:"headline"`
+	Description      string        `json:"description,omitempty"`
+	Image            string        `json:"image,omitempty"`
+	DatePublished    string        `json:"datePublished"`
+	DateModified     string        `json:"dateModified"`
+	MainEntityOfPage string        `json:"mainEntityOfPage"`
+	Author           *personSchema `json:"author,omitempty"`
+}
+
+func (routes *AllRoutes) personSchema() *personSchema {
+	authorName := routes.h.AtomSettings().AuthorName
+	if authorName == "" {
+		return nil
+	}
+	return &personSchema{
+		Type: "Person",
+		Name: authorName,
+		URL:  routes.h.SiteSettings().AbsoluteURL(aboutURL),
+	}
+}
+
+func (routes *AllRoutes) aboutSchema() *personSchema {
+	person := routes.personSchema()
+	if person == nil {
+		return nil
+	}
+	person.Context = schemaContext
+	return person
+}
+
+// aboutStructuredData is the structured data of the about page, it has none without an author
+func (routes *AllRoutes) aboutStructuredData() []interface{} {
+	person := routes.aboutSchema()
+	if person == nil {
+		return nil
+	}
+	return []interface{}{person}
+}
+
+func (routes *AllRoutes) webSiteSchema() *webSiteSchema {
+	settings := routes.h.SiteSettings()
+	return &webSiteSchema{
+		Context:     schemaContext,
+		Type:        "WebSite",
+		Name:        settings.Name,
+		URL:         settings.AbsoluteURL("/"),
+		Description: settings.Description,
+		Author:      routes.personSchema(),
+	}
+}
+
+func (routes *AllRoutes) blogPostingSchema(post *models.Post, data layoutData) *blogPostingSchema {
+	return &blogPostingSchema{
+		Context:          schemaContext,
+		Type:             "BlogPosting",
+		Headline:         post.Title,
+		Description:      post.Description,
+		Image:            data.ImageURL,
+		DatePublished:    post.PublishedAt.Format(time.RFC3339),
+		DateModified:     post.LastUpdatedAt().Format(time.RFC3339),
+		MainEntityOfPage: data.URL,
+		Author:           routes.personSchema(),
+	}
+}
@@ -0,0 +1,127 @@
+package routes
+
+import (
+	"bytes"
+	"encoding/json"
+	"html/template"
+	"path"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/s12chung/gostatic/go/test"
+
+	"github.com/s12chung/go_homepage/go/content/models"
+	"github.com/s12chung/go_homepage/go/test/mocks"
+)
+
+var structuredDataRegex = regexp.MustCompile(`(?s)<script type="application/ld\+json">(.*?)</script>`)
+
+// structuredDataJSON renders the layout with the structured data and parses the JSON-LD scripts of the result
+func structuredDataJSON(t *testing.T, context *test.Context, structuredData []interface{}) []map[string]interface{} {
+	identity := func(s string) string { return s }
+	layout, err := template.New("layout.gohtml").
+		Funcs(template.FuncMap{"title": identity, "webpackURL": identity}).
+		ParseFiles(path.Join("..", "templates", "layout.gohtml"))
+	if err != nil {
+		t.Error(context.String(err))
+		return nil
+	}
+	_, err = layout.New("content").Parse("")
+	if err != nil {
+		t.Error(context.String(err))
+		return nil
+	}
+
+	data := testLayoutData("Test", "/", nil)
+	data.StructuredData = structuredData
+	var buffer bytes.Buffer
+	err = layout.ExecuteTemplate(&buffer, "layout.gohtml", data)
+	if err != nil {
+		t.Error(context.String(err))
+		return nil
+	}
+
+	var got []map[string]interface{}
+	for _, match := range structuredDataRegex.FindAllSubmatch(buffer.Bytes(), -1) {
+		var schema map[string]interface{}
+		err = json.Unmarshal(match[1], &schema)
+		if err != nil {
+			t.Error(context.String(err))
+		}
+		got = append(got, schema)
+	}
+	return got
+}
+
+func TestAllRoutes_structuredData(t *testing.T) {
+	post := &models.Post{
+		Title:       "Post",
+		Description: "Post Description",
+		PublishedAt: time.Date(2017, 8, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt:   time.Date(2018, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+	author := map[string]interface{}{"@type": "Person", "name": "Test Author", "url": "https://test.com/about"}
+
+	testCases := []struct {
+		name       string
+		authorName string
+		data       func(routes *AllRoutes) []interface{}
+		exp        []map[string]interface{}
+	}{
+		{"about", "Test Author", func(routes *AllRoutes) []interface{} { return routes.aboutStructuredData() }, []map[string]interface{}{{
+			"@context": schemaContext,
+			"@type":    "Person",
+			"name":     "Test Author",
+			"url":      "https://test.com/about",
+		}}},
+		{"about without author", "", func(routes *AllRoutes) []interface{} { return routes.aboutStructuredData() }, nil},
+		{"website", "Test Author", func(routes *AllRoutes) []interface{} { return []interface{}{routes.webSiteSchema()} }, []map[string]interface{}{{
+			"@context":    schemaContext,
+			"@type":       "WebSite",
+			"url":         "https://test.com/",
+			"description": "Site Description",
+			"author":      author,
+		}}},
+		{"website without author", "", func(routes *AllRoutes) []interface{} { return []interface{}{routes.webSiteSchema()} }, []map[string]interface{}{{
+			"@context":    schemaContext,
+			"@type":       "WebSite",
+			"url":         "https://test.com/",
+			"description": "Site Description",
+		}}},
+		{"post", "Test Author", func(routes *AllRoutes) []interface{} {
+			return []interface{}{routes.blogPostingSchema(post, testLayoutData("Post", "/post", post))}
+		}, []map[string]interface{}{{
+			"@context":         schemaContext,
+			"@type":            "BlogPosting",
+			"headline":         "Post",
+			"description":      "Post Description",
+			"image":            "https://test.com" + testLogoURL,
+			"datePublished":    "2017-08-01T00:00:00Z",
+			"dateModified":     "2018-01-02T00:00:00Z",
+			"mainEntityOfPage": "https://test.com/post",
+			"author":           author,
+		}}},
+	}
+
+	for testCaseIndex, tc := range testCases {
+		testRoute(t, func(helper *mocks.MockHelper, ctx *mocks.MockContext) {
+			context := test.NewContext().SetFields(test.ContextFields{
+				"index": testCaseIndex,
+				"name":  tc.name,
+			})
+
+			atomSettings := testAtomSettings()
+			atomSettings.AuthorName = tc.authorName
+			helper.EXPECT().SiteSettings().Return(testSiteSettings()).AnyTimes()
+			helper.EXPECT().AtomSettings().Return(atomSettings).AnyTimes()
+
+			got := structuredDataJSON(t, context, tc.data(NewAllRoutes(helper)))
+			if !cmp.Equal(got, tc.exp) {
+				t.Error(context.DiffString("JSON-LD", got, tc.exp, cmp.Diff(got, tc.exp)))
+			}
+		})
+	}
+}
@@ -1,7 +1,14 @@
 package content
 
 import (
+	"github.com/s12chung/go_homepage/go/content/groups"
+	"github.com/s12chung/go_homepage/go/content/linkcheck"
 	"github.com/s12chung/go_homepage/go/content/models"
+	"github.com/s12chung/go_homepage/go/content/nav"
+	"github.com/s12chung/go_homepage/go/content/newsletter"
+	"github.com/s12chung/go_homepage/go/content/reading"
+	"github.com/s12chung/go_homepage/go/content/site"
+	"github.com/s12chung/go_homepage/go/content/webmention"
 
 	"github.com/s12chung/gostatic/go/lib/html"
 	"github.com/s12chung/gostatic/go/lib/webpack"
@@ -12,21 +19,41 @@ import (
 )
 
 type Settings struct {
-	Models    *models.Settings    `json:"models,omitempty"`
-	HTML      *html.Settings      `json:"html,omitempty"`
-	Atom      *atom.Settings      `json:"atom,omitempty"`
-	Goodreads *goodreads.Settings `json:"goodreads,omitempty"`
-	Markdown  *markdown.Settings  `json:"markdown,omitempty"`
-	Webpack   *webpack.Settings   `json:"webpack,omitempty"`
+	Site       *site.Settings       `json:"site,omitempty"`
+	Groups     *groups.Settings     `json:"groups,omitempty"`
+	Models     *models.Settings     `json:"models,omitempty"`
+	Nav        *nav.Settings        `json:"nav,omitempty"`
+	HTML       *html.Settings       `json:"html,omitempty"`
+	Atom       *atom.Settings       `json:"atom,omitempty"`
+	Reading    *reading.Settings    `json:"reading,omitempty"`
+	Goodreads  *goodreads.Settings  `json:"goodreads,omitempty"`
+	Markdown   *markdown.Settings   `json:"markdown,omitempty"`
+	Webpack    *webpack.Settings    `json:"webpack,omitempty"`
+	LinkCheck  *linkcheck.Settings  `json:"link_check,omitempty"`
+	Webmention *webmention.Settings `json:"webmention,omitempty"`
+	Newsletter *newsletter.Settings `json:"newsletter,omitempty"`
 }
 
 func DefaultSettings() *Settings {
 	return &Settings{
+		site.DefaultSettings(),
+		groups.DefaultSettings(),
 		models.DefaultSettings(),
+		nav.DefaultSettings(),
 		html.DefaultSettings(),
 		atom.DefaultSettings(),
+		reading.DefaultSettings(),
 		goodreads.DefaultSettings(),
 		markdown.DefaultSettings(),
 		webpack.DefaultSettings(),
+		linkcheck.DefaultSettings(),
+		webmention.DefaultSettings(),
+		newsletter.DefaultSettings(),
 	}
 }
+
+// FillDefaults defaults the settings that depend on other settings, call it after the settings file is read
+func (settings *Settings) FillDefaults() {
+	settings.Site.URLFromHost(settings.Atom.Host)
+	settings.Models.Language = settings.Site.Language
+}
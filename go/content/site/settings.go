@@ -0,0 +1,64 @@
+package site
+
+import (
+	"html/template"
+	"net/url"
+	"strings"
+
+	"github.com/s12chung/gostatic-packages/atom"
+)
+
+type Settings struct {
+	Name          string `json:"name,omitempty"`
+	URL           string `json:"url,omitempty"`
+	Description   string `json:"description,omitempty"`
+	Image         string `json:"image,omitempty"`
+	TwitterHandle string `json:"twitter_handle,omitempty"`
+	// Language is the language of the site, translated posts set their own
+	Language string `json:"language,omitempty"`
+
+	FeedEntryLimit int `json:"feed_entry_limit,omitempty"`
+}
+
+func DefaultSettings() *Settings {
+	return &Settings{
+		"",
+		"",
+		"",
+		"images/logo.png",
+		"",
+		"en",
+		atom.EntryLimit,
+	}
+}
+
+// URLFromHost defaults the URL to https://<host> when it is not set, the host is from the atom settings
+func (settings *Settings) URLFromHost(host string) {
+	if settings.URL != "" || host == "" {
+		return
+	}
+	if strings.Contains(host, "://") {
+		settings.URL = host
+		return
+	}
+	settings.URL = "https://" + host
+}
+
+// AbsoluteURL joins the urlPath to the site URL, absolute URLs are returned as is
+func (settings *Settings) AbsoluteURL(urlPath string) string {
+	u, err := url.Parse(urlPath)
+	if err == nil && u.IsAbs() {
+		return urlPath
+	}
+	if settings.URL == "" {
+		return urlPath
+	}
+	return strings.TrimRight(settings.URL, "/") + "/" + strings.TrimLeft(urlPath, "/")
+}
+
+// TemplateFuncs makes Settings a html.Plugin, so templates can build absolute URLs
+func (settings *Settings) TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"absoluteURL": settings.AbsoluteURL,
+	}
+}
@@ -0,0 +1,65 @@
+package site
+
+import (
+	"testing"
+
+	"github.com/s12chung/gostatic/go/test"
+)
+
+func TestSettings_AbsoluteURL(t *testing.T) {
+	testCases := []struct {
+		siteURL string
+		urlPath string
+		exp     string
+	}{
+		{"", "/about", "/about"},
+		{"https://test.com", "/", "https://test.com/"},
+		{"https://test.com", "/about", "https://test.com/about"},
+		{"https://test.com/", "/about", "https://test.com/about"},
+		{"https://test.com", "about", "https://test.com/about"},
+		{"https://test.com", "https://other.com/image.png", "https://other.com/image.png"},
+	}
+
+	for testCaseIndex, tc := range testCases {
+		context := test.NewContext().SetFields(test.ContextFields{
+			"index":   testCaseIndex,
+			"siteURL": tc.siteURL,
+			"urlPath": tc.urlPath,
+		})
+
+		settings := DefaultSettings()
+		settings.URL = tc.siteURL
+		got := settings.AbsoluteURL(tc.urlPath)
+		if got != tc.exp {
+			t.Error(context.GotExpString("Result", got, tc.exp))
+		}
+	}
+}
+
+func TestSettings_URLFromHost(t *testing.T) {
+	testCases := []struct {
+		siteURL string
+		host    string
+		exp     string
+	}{
+		{"", "", ""},
+		{"", "test.com", "https://test.com"},
+		{"", "http://test.com", "http://test.com"},
+		{"https://site.com", "test.com", "https://site.com"},
+	}
+
+	for testCaseIndex, tc := range testCases {
+		context := test.NewContext().SetFields(test.ContextFields{
+			"index":   testCaseIndex,
+			"siteURL": tc.siteURL,
+			"host":    tc.host,
+		})
+
+		settings := DefaultSettings()
+		settings.URL = tc.siteURL
+		settings.URLFromHost(tc.host)
+		if settings.URL != tc.exp {
+			t.Error(context.GotExpString("URL", settings.URL, tc.exp))
+		}
+	}
+}
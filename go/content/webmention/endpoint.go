@@ -0,0 +1,112 @@
+package webmention
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// linkHeaderRegex matches a link of a Link header, like: <https://example.com/webmention>; rel="webmention"
+var linkHeaderRegex = regexp.MustCompile(`<([^>]*)>\s*((?:;\s*[^;,]*)*)`)
+var relRegex = regexp.MustCompile(`(?i)rel\s*=\s*"?([^";]*)"?`)
+
+// DiscoverEndpoint returns the webmention endpoint of the target, "" when it has none,
+// see https://www.w3.org/TR/webmention/#sender-discovers-receiver-webmention-endpoint
+func (sender *Sender) DiscoverEndpoint(target string) (string, error) {
+	response, err := sender.client.Get(target)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		err := response.Body.Close()
+		if err != nil {
+			sender.log.Error(err)
+		}
+	}()
+	if response.StatusCode >= 400 {
+		return "", fmt.Errorf("%v returned status %v", target, response.StatusCode)
+	}
+
+	// relative endpoints are relative to the target after redirects
+	base := response.Request.URL
+	for _, header := range response.Header["Link"] {
+		endpoint, found := headerEndpoint(header)
+		if found {
+			return resolve(base, endpoint)
+		}
+	}
+
+	if !strings.Contains(response.Header.Get("Content-Type"), "html") {
+		return "", nil
+	}
+	endpoint, found, err := htmlEndpoint(response.Body)
+	if err != nil || !found {
+		return "", err
+	}
+	return resolve(base, endpoint)
+}
+
+func headerEndpoint(header string) (string, bool) {
+	for _, match := range linkHeaderRegex.FindAllStringSubmatch(header, -1) {
+		relMatch := relRegex.FindStringSubmatch(match[2])
+		if relMatch != nil && hasRel(relMatch[1]) {
+			return match[1], true
+		}
+	}
+	return "", false
+}
+
+// htmlEndpoint returns the href of the first <link> or <a> with a webmention rel
+func htmlEndpoint(r io.Reader) (string, bool, error) {
+	tokenizer := html.NewTokenizer(r)
+	for {
+		tokenType := tokenizer.Next()
+		switch tokenType {
+		case html.ErrorToken:
+			if tokenizer.Err() == io.EOF {
+				return "", false, nil
+			}
+			return "", false, tokenizer.Err()
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+			if token.Data != "link" && token.Data != "a" {
+				continue
+			}
+			var href string
+			var hasHref, isWebmention bool
+			for _, attr := range token.Attr {
+				switch attr.Key {
+				case "href":
+					href, hasHref = attr.Val, true
+				case "rel":
+					isWebmention = hasRel(attr.Val)
+				}
+			}
+			if hasHref && isWebmention {
+				return href, true, nil
+			}
+		}
+	}
+}
+
+func hasRel(rels string) bool {
+	for _, rel := range strings.Fields(rels) {
+		if strings.ToLower(rel) == "webmention" {
+			return true
+		}
+	}
+	return false
+}
+
+// resolve returns the endpoint relative to base, an empty endpoint is the base itself
+func resolve(base *url.URL, endpoint string) (string, error) {
+	u, err := base.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
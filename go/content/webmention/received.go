@@ -0,0 +1,105 @@
+package webmention
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// wm-property values of the received webmentions
+const (
+	likeProperty   = "like-of"
+	repostProperty = "repost-of"
+	replyProperty  = "in-reply-to"
+)
+
+type Author struct {
+	Name  string `json:"name"`
+	URL   string `json:"url"`
+	Photo string `json:"photo"`
+}
+
+type Content struct {
+	Text string `json:"text"`
+}
+
+// Mention is a received webmention, in the webmention.io jf2 format
+type Mention struct {
+	Source     string    `json:"wm-source"`
+	Target     string    `json:"wm-target"`
+	Property   string    `json:"wm-property"`
+	Private    bool      `json:"wm-private"`
+	ReceivedAt time.Time `json:"wm-received"`
+	URL        string    `json:"url"`
+	Author     Author    `json:"author"`
+	Content    Content   `json:"content"`
+}
+
+// PostMentions are the webmentions of a post by type, sorted by when they were received
+type PostMentions struct {
+	Likes    []*Mention
+	Reposts  []*Mention
+	Replies  []*Mention
+	Mentions []*Mention
+}
+
+func (mentions *PostMentions) Count() int {
+	return len(mentions.Likes) + len(mentions.Reposts) + len(mentions.Replies) + len(mentions.Mentions)
+}
+
+type feed struct {
+	Children []*Mention `json:"children"`
+}
+
+// ReceivedMentions reads the public webmentions of the ReceivedPath, a file that does not exist has none
+func ReceivedMentions(settings *Settings) ([]*Mention, error) {
+	bytes, err := ioutil.ReadFile(settings.ReceivedPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	f := feed{}
+	err = json.Unmarshal(bytes, &f)
+	if err != nil {
+		return nil, err
+	}
+
+	var mentions []*Mention
+	for _, mention := range f.Children {
+		if !mention.Private {
+			mentions = append(mentions, mention)
+		}
+	}
+	sort.SliceStable(mentions, func(i, j int) bool { return mentions[i].ReceivedAt.Before(mentions[j].ReceivedAt) })
+	return mentions, nil
+}
+
+// MentionsOf returns the mentions that target the URL path of a post, like /my-post
+func MentionsOf(mentions []*Mention, urlPath string) *PostMentions {
+	postMentions := &PostMentions{}
+	for _, mention := range mentions {
+		target, err := url.Parse(mention.Target)
+		if err != nil || strings.TrimSuffix(target.Path, "/") != urlPath {
+			continue
+		}
+
+		switch mention.Property {
+		case likeProperty:
+			postMentions.Likes = append(postMentions.Likes, mention)
+		case repostProperty:
+			postMentions.Reposts = append(postMentions.Reposts, mention)
+		case replyProperty:
+			postMentions.Replies = append(postMentions.Replies, mention)
+		default:
+			postMentions.Mentions = append(postMentions.Mentions, mention)
+		}
+	}
+	return postMentions
+}
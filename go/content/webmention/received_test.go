@@ -0,0 +1,94 @@
+package webmention
+
+import (
+	"path"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/s12chung/gostatic/go/test"
+)
+
+func testReceivedSettings() *Settings {
+	settings := DefaultSettings()
+	settings.ReceivedPath = path.Join(test.FixturePath, "webmentions.json")
+	return settings
+}
+
+func mentionSources(mentions []*Mention) []string {
+	sources := make([]string, len(mentions))
+	for i, mention := range mentions {
+		sources[i] = mention.Source
+	}
+	return sources
+}
+
+func TestReceivedMentions(t *testing.T) {
+	mentions, err := ReceivedMentions(testReceivedSettings())
+	if err != nil {
+		t.Error(err)
+	}
+	got := mentionSources(mentions)
+	exp := []string{"https://alice.test/likes/1", "https://bob.test/replies/1", "https://dan.test/reposts/1"}
+	if !cmp.Equal(got, exp) {
+		t.Error(test.NewContext().GotExpString("Result", got, exp))
+	}
+	test.AssertLabel(t, "Content", mentions[1].Content.Text, "Nice post")
+	test.AssertLabel(t, "Author", mentions[1].Author, Author{"Bob", "https://bob.test", "https://bob.test/photo.jpg"})
+
+	settings := DefaultSettings()
+	settings.ReceivedPath = "does_not_exist.json"
+	mentions, err = ReceivedMentions(settings)
+	if err != nil {
+		t.Error(err)
+	}
+	if mentions != nil {
+		t.Error(test.NewContext().GotExpString("Does not exist", mentions, nil))
+	}
+}
+
+func TestMentionsOf(t *testing.T) {
+	testCases := []struct {
+		urlPath     string
+		expLikes    []string
+		expReposts  []string
+		expReplies  []string
+		expMentions []string
+		expCount    int
+	}{
+		{"/post1", []string{"https://alice.test/likes/1"}, []string{}, []string{"https://bob.test/replies/1"}, []string{}, 2},
+		{"/post2", []string{}, []string{"https://dan.test/reposts/1"}, []string{}, []string{}, 1},
+		{"/post3", []string{}, []string{}, []string{}, []string{}, 0},
+	}
+
+	mentions, err := ReceivedMentions(testReceivedSettings())
+	if err != nil {
+		t.Error(err)
+	}
+	for testCaseIndex, tc := range testCases {
+		context := test.NewContext().SetFields(test.ContextFields{
+			"index":   testCaseIndex,
+			"urlPath": tc.urlPath,
+		})
+
+		got := MentionsOf(mentions, tc.urlPath)
+		for _, field := range []struct {
+			name     string
+			mentions []*Mention
+			exp      []string
+		}{
+			{"Likes", got.Likes, tc.expLikes},
+			{"Reposts", got.Reposts, tc.expReposts},
+			{"Replies", got.Replies, tc.expReplies},
+			{"Mentions", got.Mentions, tc.expMentions},
+		} {
+			sources := mentionSources(field.mentions)
+			if !cmp.Equal(sources, field.exp) {
+				t.Error(context.GotExpString(field.name, sources, field.exp))
+			}
+		}
+		if got.Count() != tc.expCount {
+			t.Error(context.GotExpString("Count", got.Count(), tc.expCount))
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package webmention
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/s12chung/go_homepage/go/content/linkcheck"
+)
+
+const cacheFilename = "sent.json"
+
+// Source is a post that links to the targets of the webmentions
+type Source struct {
+	URL       string
+	HTML      string
+	UpdatedAt time.Time
+}
+
+// Result is a webmention sent from Source to Target, Endpoint is empty when Target has none
+type Result struct {
+	Source     string    `json:"source"`
+	Target     string    `json:"target"`
+	Endpoint   string    `json:"endpoint,omitempty"`
+	StatusCode int       `json:"status_code,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	SentAt     time.Time `json:"sent_at"`
+}
+
+func (result *Result) Failed() bool {
+	return result.Error != "" || (result.Endpoint != "" && (result.StatusCode < 200 || result.StatusCode >= 300))
+}
+
+func (result *Result) String() string {
+	switch {
+	case result.Error != "":
+		return fmt.Sprintf("error (%v) - %v -> %v", result.Error, result.Source, result.Target)
+	case result.Endpoint == "":
+		return fmt.Sprintf("no endpoint - %v -> %v", result.Source, result.Target)
+	}
+	return fmt.Sprintf("%v - %v -> %v", result.StatusCode, result.Source, result.Target)
+}
+
+func (result *Result) key() string {
+	return result.Source + " " + result.Target
+}
+
+type Sender struct {
+	settings *Settings
+	client   *http.Client
+	log      logrus.FieldLogger
+}
+
+func NewHTTPClient(settings *Settings) *http.Client {
+	return &http.Client{Timeout: time.Duration(settings.TimeoutSeconds) * time.Second}
+}
+
+func NewSender(settings *Settings, client *http.Client, log logrus.FieldLogger) *Sender {
+	return &Sender{settings, client, log}
+}
+
+// Send sends webmentions to the external links of the sources, skipping the ones sent after the source was updated
+// and links to the source's own host, returning the results of the webmentions sent
+func (sender *Sender) Send(sources []*Source) ([]*Result, error) {
+	cache, err := sender.readCache()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*Result
+	for _, source := range sources {
+		sourceURL, err := url.Parse(source.URL)
+		if err != nil {
+			return nil, err
+		}
+		links, err := linkcheck.ExternalLinks(source.HTML)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, link := range links {
+			target, err := url.Parse(link)
+			if err != nil || target.Host == sourceURL.Host {
+				continue
+			}
+			result := &Result{Source: source.URL, Target: link}
+			cached := cache[result.key()]
+			if cached != nil && !cached.Failed() && cached.SentAt.After(source.UpdatedAt) {
+				continue
+			}
+
+			result = sender.send(source.URL, link)
+			cache[result.key()] = result
+			results = append(results, result)
+		}
+	}
+	return results, sender.writeCache(cache)
+}
+
+func (sender *Sender) send(source, target string) *Result {
+	result := &Result{Source: source, Target: target, SentAt: time.Now()}
+
+	endpoint, err := sender.DiscoverEndpoint(target)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	if endpoint == "" {
+		return result
+	}
+	result.Endpoint = endpoint
+
+	sender.log.Infof("Sending webmention %v -> %v to %v", source, target, endpoint)
+	response, err := sender.client.PostForm(endpoint, url.Values{"source": {source}, "target": {target}})
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	defer func() {
+		err := response.Body.Close()
+		if err != nil {
+			sender.log.Error(err)
+		}
+	}()
+	result.StatusCode = response.StatusCode
+	return result
+}
+
+func (sender *Sender) cacheFilePath() string {
+	return filepath.Join(sender.settings.CachePath, cacheFilename)
+}
+
+func (sender *Sender) readCache() (map[string]*Result, error) {
+	cache := map[string]*Result{}
+
+	bytes, err := ioutil.ReadFile(sender.cacheFilePath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cache, nil
+		}
+		return nil, err
+	}
+
+	var results []*Result
+	err = json.Unmarshal(bytes, &results)
+	if err != nil {
+		return nil, err
+	}
+	for _, result := range results {
+		cache[result.key()] = result
+	}
+	return cache, nil
+}
+
+func (sender *Sender) writeCache(cache map[string]*Result) error {
+	results := make([]*Result, 0, len(cache))
+	for _, result := range cache {
+		results = append(results, result)
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].key() < results[j].key() })
+
+	bytes, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(sender.settings.CachePath, 0755)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(sender.cacheFilePath(), bytes, 0644)
+}
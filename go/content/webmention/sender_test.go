@@ -0,0 +1,159 @@
+package webmention
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+
+	"github.com/s12chung/gostatic/go/test"
+)
+
+type received struct {
+	source string
+	target string
+}
+
+// testServer has targets with each kind of endpoint discovery and records the webmentions received
+func testServer(receivedMentions *[]received) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/header":
+			w.Header().Add("Link", `<https://other.test/feed>; rel="alternate", </endpoint?from=header>; rel="webmention"`)
+		case "/link":
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = w.Write([]byte(`<html><head><link rel="stylesheet" href="/style.css"><link rel="webmention" href="endpoint?from=link"></head></html>`))
+		case "/a":
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = w.Write([]byte(`<html><body><a rel="nofollow webmention" href="/endpoint?from=a">Webmention</a></body></html>`))
+		case "/empty":
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = w.Write([]byte(`<html><head><link rel="webmention" href=""></head></html>`))
+		case "/redirect":
+			http.Redirect(w, r, "/link", http.StatusFound)
+		case "/none":
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = w.Write([]byte(`<html><body><a href="/endpoint">Not webmention</a></body></html>`))
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		case "/endpoint":
+			if r.Method != http.MethodPost {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			*receivedMentions = append(*receivedMentions, received{r.FormValue("source"), r.FormValue("target")})
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+}
+
+func testSender(t *testing.T) (*Sender, func()) {
+	log, _ := logTest.NewNullLogger()
+	settings := DefaultSettings()
+	cachePath, clean := test.SandboxDir(t, settings.CachePath)
+	settings.CachePath = cachePath
+	return NewSender(settings, NewHTTPClient(settings), log), clean
+}
+
+func TestSender_DiscoverEndpoint(t *testing.T) {
+	var receivedMentions []received
+	server := testServer(&receivedMentions)
+	defer server.Close()
+	sender, clean := testSender(t)
+	defer clean()
+
+	testCases := []struct {
+		path     string
+		exp      string
+		hasError bool
+	}{
+		{"/header", server.URL + "/endpoint?from=header", false},
+		{"/link", server.URL + "/endpoint?from=link", false},
+		{"/a", server.URL + "/endpoint?from=a", false},
+		{"/empty", server.URL + "/empty", false},
+		{"/redirect", server.URL + "/endpoint?from=link", false},
+		{"/none", "", false},
+		{"/missing", "", true},
+	}
+
+	for testCaseIndex, tc := range testCases {
+		context := test.NewContext().SetFields(test.ContextFields{
+			"index": testCaseIndex,
+			"path":  tc.path,
+		})
+
+		got, err := sender.DiscoverEndpoint(server.URL + tc.path)
+		if tc.hasError != (err != nil) {
+			t.Error(context.GotExpString("err", err, tc.hasError))
+		}
+		if got != tc.exp {
+			t.Error(context.GotExpString("Result", got, tc.exp))
+		}
+	}
+}
+
+func resultStrings(results []*Result) []string {
+	strings := make([]string, len(results))
+	for i, result := range results {
+		strings[i] = result.String()
+	}
+	sort.Strings(strings)
+	return strings
+}
+
+func TestSender_Send(t *testing.T) {
+	var receivedMentions []received
+	server := testServer(&receivedMentions)
+	defer server.Close()
+	sender, clean := testSender(t)
+	defer clean()
+
+	source := &Source{
+		"https://test.com/post1",
+		fmt.Sprintf(`<a href="%[1]v/header">h</a> <a href="%[1]v/none">n</a> <a href="%[1]v/missing">m</a> <a href="/relative">r</a> <a href="https://test.com/post2">self</a>`, server.URL),
+		time.Now().Add(-time.Hour),
+	}
+
+	results, err := sender.Send([]*Source{source})
+	if err != nil {
+		t.Error(err)
+	}
+	got := resultStrings(results)
+	exp := []string{
+		"202 - https://test.com/post1 -> " + server.URL + "/header",
+		"error (" + server.URL + "/missing returned status 404) - https://test.com/post1 -> " + server.URL + "/missing",
+		"no endpoint - https://test.com/post1 -> " + server.URL + "/none",
+	}
+	if !cmp.Equal(got, exp) {
+		t.Error(test.NewContext().DiffString("Result", got, exp, cmp.Diff(got, exp)))
+	}
+	expReceived := []received{{"https://test.com/post1", server.URL + "/header"}}
+	if !cmp.Equal(receivedMentions, expReceived, cmp.AllowUnexported(received{})) {
+		t.Error(test.NewContext().GotExpString("receivedMentions", receivedMentions, expReceived))
+	}
+
+	// only the failed webmention is sent again
+	results, err = sender.Send([]*Source{source})
+	if err != nil {
+		t.Error(err)
+	}
+	got = resultStrings(results)
+	exp = []string{"error (" + server.URL + "/missing returned status 404) - https://test.com/post1 -> " + server.URL + "/missing"}
+	if !cmp.Equal(got, exp) {
+		t.Error(test.NewContext().DiffString("Cached", got, exp, cmp.Diff(got, exp)))
+	}
+
+	// updated sources are sent again
+	source.UpdatedAt = time.Now()
+	results, err = sender.Send([]*Source{source})
+	if err != nil {
+		t.Error(err)
+	}
+	test.AssertLabel(t, "Updated", len(results), 3)
+	test.AssertLabel(t, "len(receivedMentions)", len(receivedMentions), 2)
+}
@@ -0,0 +1,17 @@
+package webmention
+
+type Settings struct {
+	// CachePath has the webmentions sent, so they are only sent again for updated posts
+	CachePath      string `json:"cache_path,omitempty"`
+	TimeoutSeconds int    `json:"timeout_seconds,omitempty"`
+	// ReceivedPath is a JSON file of the received webmentions, in the webmention.io jf2 format
+	ReceivedPath string `json:"received_path,omitempty"`
+}
+
+func DefaultSettings() *Settings {
+	return &Settings{
+		"./cache/webmention",
+		10,
+		"./content/data/webmentions.json",
+	}
+}
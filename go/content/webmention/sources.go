@@ -0,0 +1,25 @@
+package webmention
+
+import (
+	"fmt"
+
+	"github.com/s12chung/go_homepage/go/content/models"
+	"github.com/s12chung/go_homepage/go/content/site"
+)
+
+// PostSources are the published posts, with absolute URLs
+func PostSources(siteSettings *site.Settings) ([]*Source, error) {
+	if siteSettings.URL == "" {
+		return nil, fmt.Errorf("the site url setting is needed for the source URLs of webmentions")
+	}
+
+	posts, err := models.Posts()
+	if err != nil {
+		return nil, err
+	}
+	sources := make([]*Source, len(posts))
+	for i, post := range posts {
+		sources[i] = &Source{siteSettings.AbsoluteURL(post.URL()), post.MarkdownHTML, post.LastUpdatedAt()}
+	}
+	return sources, nil
+}
@@ -6,9 +6,16 @@ package mocks
 
 import (
 	gomock "github.com/golang/mock/gomock"
-	atom "github.com/s12chung/gostatic-packages/atom"
+	atom "github.com/s12chung/go_homepage/go/content/atom"
+	groups "github.com/s12chung/go_homepage/go/content/groups"
+	nav "github.com/s12chung/go_homepage/go/content/nav"
+	reading "github.com/s12chung/go_homepage/go/content/reading"
+	site "github.com/s12chung/go_homepage/go/content/site"
+	webmention "github.com/s12chung/go_homepage/go/content/webmention"
+	atom0 "github.com/s12chung/gostatic-packages/atom"
 	goodreads "github.com/s12chung/gostatic-packages/goodreads"
 	router "github.com/s12chung/gostatic/go/lib/router"
+	logrus "github.com/sirupsen/logrus"
 	reflect "reflect"
 )
 
@@ -35,6 +42,18 @@ func (m *MockHelper) EXPECT() *MockHelperMockRecorder {
 	return m.recorder
 }
 
+// AtomSettings mocks base method
+func (m *MockHelper) AtomSettings() *atom0.Settings {
+	ret := m.ctrl.Call(m, "AtomSettings")
+	ret0, _ := ret[0].(*atom0.Settings)
+	return ret0
+}
+
+// AtomSettings indicates an expected call of AtomSettings
+func (mr *MockHelperMockRecorder) AtomSettings() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AtomSettings", reflect.TypeOf((*MockHelper)(nil).AtomSettings))
+}
+
 // GoodreadsSettings mocks base method
 func (m *MockHelper) GoodreadsSettings() *goodreads.Settings {
 	ret := m.ctrl.Call(m, "GoodreadsSettings")
@@ -47,6 +66,30 @@ func (mr *MockHelperMockRecorder) GoodreadsSettings() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GoodreadsSettings", reflect.TypeOf((*MockHelper)(nil).GoodreadsSettings))
 }
 
+// GroupsSettings mocks base method
+func (m *MockHelper) GroupsSettings() *groups.Settings {
+	ret := m.ctrl.Call(m, "GroupsSettings")
+	ret0, _ := ret[0].(*groups.Settings)
+	return ret0
+}
+
+// GroupsSettings indicates an expected call of GroupsSettings
+func (mr *MockHelperMockRecorder) GroupsSettings() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupsSettings", reflect.TypeOf((*MockHelper)(nil).GroupsSettings))
+}
+
+// Log mocks base method
+func (m *MockHelper) Log() logrus.FieldLogger {
+	ret := m.ctrl.Call(m, "Log")
+	ret0, _ := ret[0].(logrus.FieldLogger)
+	return ret0
+}
+
+// Log indicates an expected call of Log
+func (mr *MockHelperMockRecorder) Log() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Log", reflect.TypeOf((*MockHelper)(nil).Log))
+}
+
 // ManifestURL mocks base method
 func (m *MockHelper) ManifestURL(arg0 string) string {
 	ret := m.ctrl.Call(m, "ManifestURL", arg0)
@@ -59,16 +102,40 @@ func (mr *MockHelperMockRecorder) ManifestURL(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ManifestURL", reflect.TypeOf((*MockHelper)(nil).ManifestURL), arg0)
 }
 
+// NavSettings mocks base method
+func (m *MockHelper) NavSettings() *nav.Settings {
+	ret := m.ctrl.Call(m, "NavSettings")
+	ret0, _ := ret[0].(*nav.Settings)
+	return ret0
+}
+
+// NavSettings indicates an expected call of NavSettings
+func (mr *MockHelperMockRecorder) NavSettings() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NavSettings", reflect.TypeOf((*MockHelper)(nil).NavSettings))
+}
+
+// ReadingSettings mocks base method
+func (m *MockHelper) ReadingSettings() *reading.Settings {
+	ret := m.ctrl.Call(m, "ReadingSettings")
+	ret0, _ := ret[0].(*reading.Settings)
+	return ret0
+}
+
+// ReadingSettings indicates an expected call of ReadingSettings
+func (mr *MockHelperMockRecorder) ReadingSettings() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadingSettings", reflect.TypeOf((*MockHelper)(nil).ReadingSettings))
+}
+
 // RespondAtom mocks base method
-func (m *MockHelper) RespondAtom(arg0 router.Context, arg1, arg2 string, arg3 []*atom.HTMLEntry) error {
-	ret := m.ctrl.Call(m, "RespondAtom", arg0, arg1, arg2, arg3)
+func (m *MockHelper) RespondAtom(arg0 router.Context, arg1, arg2 string, arg3 []*atom0.HTMLEntry, arg4 *atom.History) error {
+	ret := m.ctrl.Call(m, "RespondAtom", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // RespondAtom indicates an expected call of RespondAtom
-func (mr *MockHelperMockRecorder) RespondAtom(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RespondAtom", reflect.TypeOf((*MockHelper)(nil).RespondAtom), arg0, arg1, arg2, arg3)
+func (mr *MockHelperMockRecorder) RespondAtom(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RespondAtom", reflect.TypeOf((*MockHelper)(nil).RespondAtom), arg0, arg1, arg2, arg3, arg4)
 }
 
 // RespondHTML mocks base method
@@ -82,3 +149,27 @@ func (m *MockHelper) RespondHTML(arg0 router.Context, arg1 string, arg2 interfac
 func (mr *MockHelperMockRecorder) RespondHTML(arg0, arg1, arg2 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RespondHTML", reflect.TypeOf((*MockHelper)(nil).RespondHTML), arg0, arg1, arg2)
 }
+
+// SiteSettings mocks base method
+func (m *MockHelper) SiteSettings() *site.Settings {
+	ret := m.ctrl.Call(m, "SiteSettings")
+	ret0, _ := ret[0].(*site.Settings)
+	return ret0
+}
+
+// SiteSettings indicates an expected call of SiteSettings
+func (mr *MockHelperMockRecorder) SiteSettings() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SiteSettings", reflect.TypeOf((*MockHelper)(nil).SiteSettings))
+}
+
+// WebmentionSettings mocks base method
+func (m *MockHelper) WebmentionSettings() *webmention.Settings {
+	ret := m.ctrl.Call(m, "WebmentionSettings")
+	ret0, _ := ret[0].(*webmention.Settings)
+	return ret0
+}
+
+// WebmentionSettings indicates an expected call of WebmentionSettings
+func (mr *MockHelperMockRecorder) WebmentionSettings() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WebmentionSettings", reflect.TypeOf((*MockHelper)(nil).WebmentionSettings))
+}
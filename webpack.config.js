@@ -12,6 +12,7 @@ module.exports = {
 
     entry: Object.assign(defaults.entry(), {
         // entryChunkName: relativePath('assets/js/filename.js'),
+        not_found: relativePath('assets/js/not_found.js'),
     }),
     output: Object.assign(defaults.output(), {
         // customize